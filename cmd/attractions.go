@@ -66,6 +66,13 @@ func runAttractions(cmd *cobra.Command, args []string) error {
 		results = results[:attrLimit]
 	}
 
+	g := newGeocoder(loadVenues())
+	for i, a := range results {
+		pt, links := mapLinks(g, a.Name, a.Name, a.Address)
+		results[i].Lat, results[i].Lon = pt.Lat, pt.Lon
+		results[i].MapURL, results[i].OSMURL, results[i].GeoURI = links.Google, links.OSM, links.GeoURI
+	}
+
 	if attrJSON {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/cache"
+	"github.com/havocked/leipzig-cli/internal/news"
+	"github.com/havocked/leipzig-cli/internal/playground"
+	"github.com/spf13/cobra"
+)
+
+const (
+	eventsTTL      = cache.DefaultTTL
+	newsTTL        = 15 * time.Minute
+	playgroundsTTL = 24 * time.Hour
+
+	playgroundsCacheKey = "playgrounds"
+)
+
+var (
+	cacheWarmWhen        string
+	cacheWarmPlaygrounds bool
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and manage the local cache",
+	Long: `Inspect and manage the on-disk cache of fetched events, news and playgrounds.
+
+Examples:
+  leipzig cache status                    # Show cached entries and their age
+  leipzig cache clear                     # Remove everything
+  leipzig cache clear prinz.de            # Remove one source's entries
+  leipzig cache warm --when today,weekend # Pre-fetch events`,
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show cached entries",
+	RunE:  runCacheStatus,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear [source...]",
+	Short: "Remove cached entries (all, or only the given sources)",
+	RunE:  runCacheClear,
+}
+
+var cacheWarmCmd = &cobra.Command{
+	Use:   "warm",
+	Short: "Pre-fetch data into the cache",
+	RunE:  runCacheWarm,
+}
+
+func init() {
+	cacheWarmCmd.Flags().StringVar(&cacheWarmWhen, "when", "today,tomorrow,weekend", "Time ranges to warm (comma-separated)")
+	cacheWarmCmd.Flags().BoolVar(&cacheWarmPlaygrounds, "playgrounds", false, "Also refresh the playground list")
+	cacheCmd.AddCommand(cacheStatusCmd, cacheClearCmd, cacheWarmCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+// openCache returns the cache store, or nil if caching is disabled or the
+// cache directory is unusable.
+func openCache() *cache.Store {
+	if flagNoCache {
+		return nil
+	}
+	store, err := mustOpenCache()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v (caching disabled)\n", err)
+		return nil
+	}
+	return store
+}
+
+func mustOpenCache() (*cache.Store, error) {
+	dir := cfg.CacheDir()
+	if dir == "" {
+		var err error
+		if dir, err = cache.DefaultDir(); err != nil {
+			return nil, err
+		}
+	}
+	return cache.Open(dir)
+}
+
+func runCacheStatus(cmd *cobra.Command, args []string) error {
+	store, err := mustOpenCache()
+	if err != nil {
+		return err
+	}
+	entries, err := store.Entries()
+	if err != nil {
+		return fmt.Errorf("list cache: %w", err)
+	}
+
+	fmt.Printf("Cache directory: %s\n\n", store.Dir())
+	if len(entries) == 0 {
+		fmt.Println("Cache is empty.")
+		return nil
+	}
+
+	now := time.Now()
+	var total int64
+	fmt.Printf("%-15s %-8s %-8s %8s  %s\n", "SOURCE", "AGE", "STATUS", "SIZE", "KEY")
+	for _, e := range entries {
+		status := "fresh"
+		if e.Age(now) >= ttlFor(e.Source) {
+			status = "stale"
+		}
+		fmt.Printf("%-15s %-8s %-8s %8s  %s\n", e.Source, formatAge(e.Age(now)), status, formatSize(e.Size()), e.Key)
+		total += e.Size()
+	}
+	fmt.Fprintf(os.Stderr, "\n%d entries, %s\n", len(entries), formatSize(total))
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	store, err := mustOpenCache()
+	if err != nil {
+		return err
+	}
+	n, err := store.Clear(args...)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Removed %d cache entries\n", n)
+	return nil
+}
+
+func runCacheWarm(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	store, err := mustOpenCache()
+	if err != nil {
+		return err
+	}
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	now := time.Now().In(loc)
+
+	for _, when := range strings.Split(cacheWarmWhen, ",") {
+		when = strings.TrimSpace(when)
+		if when == "" {
+			continue
+		}
+		rng, err := resolveTimeRange(when, now)
+		if err != nil {
+			return err
+		}
+		from, to := rng.From, rng.To
+		for _, src := range eventSources() {
+			fmt.Fprintf(os.Stderr, "Warming %s (%s)...\n", src.ID(), when)
+			events, err := src.Fetch(ctx, from, to)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: source %s failed: %v\n", src.ID(), err)
+				continue
+			}
+			if err := store.Put(cache.EventsKey(src.ID(), from, to), src.ID(), events); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cacheWarmPlaygrounds {
+		fmt.Fprintf(os.Stderr, "Warming playgrounds...\n")
+		all, err := playground.FetchAll()
+		if err != nil {
+			return fmt.Errorf("fetching playgrounds: %w", err)
+		}
+		if err := store.Put(playgroundsCacheKey, "playgrounds", all); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newsCacheKey is the cache key for a news query.
+func newsCacheKey(opts news.FetchOptions) string {
+	return fmt.Sprintf("news|%d|%s|%s", opts.Pages, opts.Category, opts.Search)
+}
+
+func ttlFor(source string) time.Duration {
+	switch source {
+	case "news":
+		return cfg.TTL(source, newsTTL)
+	case "playgrounds":
+		return cfg.TTL(source, playgroundsTTL)
+	default:
+		return cfg.TTL(source, eventsTTL)
+	}
+}
+
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%dB", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1fK", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1fM", float64(n)/(1024*1024))
+	}
+}
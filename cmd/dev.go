@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/httpx"
+	"github.com/havocked/leipzig-cli/internal/news"
+	"github.com/havocked/leipzig-cli/internal/playground"
+	"github.com/havocked/leipzig-cli/internal/source/leipzigde"
+	"github.com/havocked/leipzig-cli/internal/source/prinzde"
+	"github.com/spf13/cobra"
+)
+
+var devRecordDir string
+
+// recordTargets are the scrapers whose tests replay recorded fixtures.
+// dir is relative to the repository root.
+var recordTargets = map[string]struct {
+	dir    string
+	record func(ctx context.Context, client *http.Client, from, to time.Time) error
+}{
+	"leipzig.de": {"internal/source/leipzigde/testdata/http", func(ctx context.Context, client *http.Client, from, to time.Time) error {
+		_, err := leipzigde.NewWithOptions(leipzigde.Options{Client: client}).Fetch(ctx, from, to)
+		return err
+	}},
+	"prinz.de": {"internal/source/prinzde/testdata/http", func(ctx context.Context, client *http.Client, from, to time.Time) error {
+		_, err := prinzde.NewWithOptions(prinzde.Options{Client: client}).Fetch(ctx, from, to)
+		return err
+	}},
+	"news": {"internal/news/testdata/http", func(ctx context.Context, client *http.Client, from, to time.Time) error {
+		_, err := news.Fetch(news.FetchOptions{Pages: 2, Client: client})
+		return err
+	}},
+	"playgrounds": {"internal/playground/testdata/http", func(ctx context.Context, client *http.Client, from, to time.Time) error {
+		_, err := playground.FetchAllWith(client)
+		return err
+	}},
+}
+
+var devCmd = &cobra.Command{
+	Use:    "dev",
+	Short:  "Tools for working on leipzig itself",
+	Hidden: true,
+}
+
+var devRecordCmd = &cobra.Command{
+	Use:   "record <source>",
+	Short: "Refresh a scraper's HTTP test fixtures from the live site",
+	Long: `Fetch today's data from the live site and store every response under the
+scraper's testdata/http directory, replacing the old fixtures. Afterwards run
+"UPDATE_GOLDEN=1 go test ./..." and review the golden file diff.
+
+Sources: leipzig.de, prinz.de, news, playgrounds`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: recordTargetNames(),
+	RunE:      runDevRecord,
+}
+
+func init() {
+	devRecordCmd.Flags().StringVar(&devRecordDir, "dir", "", "Fixture directory (default: the scraper's testdata/http)")
+	devCmd.AddCommand(devRecordCmd)
+	rootCmd.AddCommand(devCmd)
+}
+
+func recordTargetNames() []string {
+	var names []string
+	for name := range recordTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runDevRecord(cmd *cobra.Command, args []string) error {
+	target, ok := recordTargets[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown source %q (want one of %v)", args[0], recordTargetNames())
+	}
+	dir := devRecordDir
+	if dir == "" {
+		dir = target.dir
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Record next to dir and only replace the old fixtures once recording
+	// succeeded, so a failed or interrupted run leaves them intact.
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), ".record-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 1)
+
+	client := httpx.Record(tmp, httpx.NewTransport(httpx.Options{})).Client()
+	if err := target.record(ctx, client, from, to); err != nil {
+		return fmt.Errorf("recording %s: %w", args[0], err)
+	}
+	if err := httpx.SaveRecording(tmp, httpx.Recording{RecordedAt: now, From: from, To: to}); err != nil {
+		return err
+	}
+
+	recorded, err := replaceFixtures(tmp, dir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Recorded %d responses to %s\n", recorded, dir)
+	return nil
+}
+
+// replaceFixtures moves a finished recording from tmp into dir, replacing
+// dir's old fixtures, and returns the number of responses.
+func replaceFixtures(tmp, dir string) (int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	old, _ := filepath.Glob(filepath.Join(dir, "*.http"))
+	for _, f := range old {
+		if err := os.Remove(f); err != nil {
+			return 0, err
+		}
+	}
+	files, err := os.ReadDir(tmp)
+	if err != nil {
+		return 0, err
+	}
+	recorded := 0
+	for _, f := range files {
+		if err := os.Rename(filepath.Join(tmp, f.Name()), filepath.Join(dir, f.Name())); err != nil {
+			return recorded, err
+		}
+		if filepath.Ext(f.Name()) == ".http" {
+			recorded++
+		}
+	}
+	return recorded, nil
+}
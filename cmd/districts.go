@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/cache"
+	"github.com/havocked/leipzig-cli/internal/district"
+	"github.com/havocked/leipzig-cli/internal/market"
+	"github.com/havocked/leipzig-cli/internal/playground"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagDistrictsWhen string
+	flagDistrictsJSON bool
+)
+
+var districtsCmd = &cobra.Command{
+	Use:   "districts [stadtbezirk]",
+	Short: "List Leipzig's districts with events, playgrounds and markets",
+	Long: `List Leipzig's 10 Stadtbezirke and their 63 Ortsteile with the number of
+upcoming events, public playgrounds and weekly markets in each. Given a
+Stadtbezirk, list only its Ortsteile.
+
+Any of these names works for --district on events, playgrounds and
+markets. Umlauts may be spelled out (Suedvorstadt) or dropped (Sudvorstadt).
+
+Examples:
+  leipzig districts
+  leipzig districts Süd --when weekend
+  leipzig events --district Connewitz`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeDistrict,
+	RunE:              runDistricts,
+}
+
+func init() {
+	districtsCmd.Flags().StringVar(&flagDistrictsWhen, "when", "week", "Time range to count events in, e.g. today, weekend, next friday, 2026-03-01..2026-03-07")
+	districtsCmd.Flags().BoolVar(&flagDistrictsJSON, "json", false, "Output as JSON")
+	rootCmd.AddCommand(districtsCmd)
+}
+
+func runDistricts(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var only string
+	if len(args) == 1 {
+		var err error
+		if only, err = parseDistrict(args[0]); err != nil {
+			return err
+		}
+	}
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	rng, err := resolveTimeRange(flagDistrictsWhen, time.Now().In(loc))
+	if err != nil {
+		return err
+	}
+	events, err := fetchEvents(ctx, rng.From, rng.To, false)
+	if err != nil {
+		return err
+	}
+	playgrounds, err := cache.Load(openCache(), playgroundsCacheKey, "playgrounds", ttlFor("playgrounds"), playground.FetchAll)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: playgrounds: %v\n", err)
+	}
+
+	counts := map[string]*districtInfo{}
+	var unknown districtInfo
+	// count counts one item in its district and, for an Ortsteil, in its
+	// Stadtbezirk.
+	count := func(field func(*districtInfo) *int, name string) {
+		d, ok := district.Lookup(name)
+		if !ok {
+			*field(&unknown)++
+			return
+		}
+		for _, n := range []string{d.Name, d.Parent} {
+			if n == "" {
+				continue
+			}
+			if counts[n] == nil {
+				counts[n] = &districtInfo{}
+			}
+			*field(counts[n])++
+		}
+	}
+	for _, e := range events {
+		count(func(i *districtInfo) *int { return &i.Events }, e.District)
+	}
+	for _, p := range playgrounds {
+		count(func(i *districtInfo) *int { return &i.Playgrounds }, playgroundDistrict(p))
+	}
+	for _, m := range market.Markets {
+		count(func(i *districtInfo) *int { return &i.Markets }, m.Ortsteil)
+	}
+
+	var infos []districtInfo
+	for _, d := range district.All() {
+		if only != "" && !district.Within(d.Name, only) {
+			continue
+		}
+		info := districtInfo{District: d}
+		if c := counts[d.Name]; c != nil {
+			info.Events, info.Playgrounds, info.Markets = c.Events, c.Playgrounds, c.Markets
+		}
+		infos = append(infos, info)
+	}
+
+	if flagDistrictsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(infos)
+	}
+	fmt.Printf("%-32s %6s %11s %7s\n", "DISTRICT", "EVENTS", "PLAYGROUNDS", "MARKETS")
+	for _, i := range infos {
+		name := i.Name
+		if i.IsOrtsteil() {
+			name = "  " + name
+		}
+		fmt.Printf("%-32s %6d %11d %7d\n", name, i.Events, i.Playgrounds, i.Markets)
+	}
+	if only == "" && unknown.Events+unknown.Playgrounds > 0 {
+		fmt.Fprintf(os.Stderr, "\nWithout a known district: %d events, %d playgrounds\n", unknown.Events, unknown.Playgrounds)
+	}
+	return nil
+}
+
+// districtInfo is a district with its counts.
+type districtInfo struct {
+	district.District
+	Events      int `json:"events"`
+	Playgrounds int `json:"playgrounds"`
+	Markets     int `json:"markets"`
+}
+
+// districtFlag adds a validated, shell-completed --district flag to cmd.
+func districtFlag(cmd *cobra.Command, p *string) {
+	cmd.Flags().StringVarP(p, "district", "d", "", "Filter by Ortsteil or Stadtbezirk (see: leipzig districts)")
+	cmd.RegisterFlagCompletionFunc("district", completeDistrict)
+}
+
+func completeDistrict(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return district.Complete(toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// parseDistrict returns the canonical name of a --district value; "" stays
+// "".
+func parseDistrict(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	d, ok := district.Lookup(s)
+	if !ok {
+		return "", fmt.Errorf("unknown district %q (see: leipzig districts)", s)
+	}
+	return d.Name, nil
+}
+
+// playgroundDistrict returns the playground's Ortsteil, or its Stadtbezirk
+// if the Ortsteil is unknown.
+func playgroundDistrict(p playground.Playground) string {
+	if _, ok := district.Lookup(p.Subdistrict); ok {
+		return p.Subdistrict
+	}
+	return p.District
+}
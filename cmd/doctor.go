@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/config"
+	"github.com/havocked/leipzig-cli/internal/geo"
+	"github.com/havocked/leipzig-cli/internal/health"
+	"github.com/havocked/leipzig-cli/internal/source"
+	"github.com/havocked/leipzig-cli/internal/source/selector"
+	"github.com/havocked/leipzig-cli/internal/timerange"
+	"github.com/havocked/leipzig-cli/internal/venue"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagCheckWhen string
+	flagCheckJSON bool
+)
+
+var sourcesCheckCmd = &cobra.Command{
+	Use:   "check [source...]",
+	Short: "Fetch from each source and report whether its output looks sane",
+	Long: `Fetch from each enabled source (or the given ones), bypassing the cache, and
+report HTTP status, latency, event count and the share of events missing a
+time, venue or category. Anomalies such as "0 events parsed from a 200 OK
+page" make the command exit non-zero, so it can run from cron.
+
+Examples:
+  leipzig sources check                  # Check all enabled sources
+  leipzig sources check leipzig.de       # Check one source
+  leipzig sources check --json           # Machine-readable report`,
+	RunE: runSourcesCheck,
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check config, cache and every source",
+	Long: `Check that the config file, selector definitions, venues and imported
+addresses load, the cache directory is writable and every enabled source
+returns sane events. Exits non-zero if anything is wrong.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{sourcesCheckCmd, doctorCmd} {
+		c.Flags().StringVar(&flagCheckWhen, "when", "week", "Time range to fetch, e.g. today, weekend, next friday, 2026-03-01..2026-03-07")
+	}
+	sourcesCheckCmd.Flags().BoolVar(&flagCheckJSON, "json", false, "Output as JSON")
+	sourcesCmd.AddCommand(sourcesCheckCmd)
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runSourcesCheck(cmd *cobra.Command, args []string) error {
+	sources, err := sourcesToCheck(args)
+	if err != nil {
+		return err
+	}
+
+	rng, err := checkRange()
+	if err != nil {
+		return err
+	}
+	reports := checkSources(sources, rng)
+	if flagCheckJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			return fmt.Errorf("json encode: %w", err)
+		}
+	} else {
+		printReports(reports)
+	}
+
+	if n := unhealthy(reports); n > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d sources unhealthy", n, len(reports))
+	}
+	return nil
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	problems := 0
+	check := func(name string, err error) {
+		if err != nil {
+			problems++
+			fmt.Printf("FAIL  %-22s %v\n", name, err)
+			return
+		}
+		fmt.Printf("ok    %s\n", name)
+	}
+
+	check("config "+cfg.File(), nil)
+
+	check("cache directory", func() error {
+		store, err := mustOpenCache()
+		if err != nil {
+			return err
+		}
+		f, err := os.CreateTemp(store.Dir(), ".doctor-*")
+		if err != nil {
+			return fmt.Errorf("not writable: %w", err)
+		}
+		f.Close()
+		return os.Remove(f.Name())
+	}())
+
+	check("selector definitions", func() error {
+		dir, err := selectorDir()
+		if err != nil {
+			return err
+		}
+		_, err = selector.LoadDir(dir)
+		return err
+	}())
+
+	check("venues", func() error {
+		dir, err := config.Dir()
+		if err != nil {
+			return err
+		}
+		_, err = venue.Load(filepath.Join(dir, "venues.yaml"))
+		return err
+	}())
+
+	check("addresses", func() error {
+		dir, err := config.Dir()
+		if err != nil {
+			return err
+		}
+		_, err = geo.LoadAddresses(filepath.Join(dir, addressesFile))
+		return err
+	}())
+
+	sources := eventSources()
+	if len(sources) == 0 {
+		check("event sources", fmt.Errorf("none enabled"))
+	}
+	fmt.Println()
+	rng, err := checkRange()
+	if err != nil {
+		return err
+	}
+	reports := checkSources(sources, rng)
+	printReports(reports)
+	problems += unhealthy(reports)
+
+	if problems > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d problems found", problems)
+	}
+	return nil
+}
+
+// sourcesToCheck returns the named sources, or all enabled ones.
+func sourcesToCheck(ids []string) ([]source.Source, error) {
+	if len(ids) == 0 {
+		return eventSources(), nil
+	}
+	extra := runtimeSources()
+	var sources []source.Source
+	for _, id := range ids {
+		src := newSource(id, extra)
+		if src == nil {
+			if isKnownSource(id, extra) {
+				return nil, fmt.Errorf("source %s is not configured", id)
+			}
+			return nil, fmt.Errorf("unknown source %q", id)
+		}
+		sources = append(sources, src)
+	}
+	return sources, nil
+}
+
+// checkSources checks all sources concurrently; reports keep source order.
+func checkSources(sources []source.Source, rng timerange.Range) []health.Report {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	reports := make([]health.Report, len(sources))
+	var wg sync.WaitGroup
+	for i, src := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, sourceTimeout(src.ID()))
+			defer cancel()
+			reports[i] = health.Check(ctx, src, rng.From, rng.To)
+		}()
+	}
+	wg.Wait()
+	return reports
+}
+
+func printReports(reports []health.Report) {
+	fmt.Printf("%-15s %-6s %8s %5s %7s %8s %9s %7s  %s\n",
+		"SOURCE", "HEALTH", "LATENCY", "REQS", "EVENTS", "NO TIME", "NO VENUE", "NO CAT", "HTTP")
+	for _, r := range reports {
+		state := "ok"
+		if !r.OK() {
+			state = "FAIL"
+		}
+		fmt.Printf("%-15s %-6s %8s %5d %7d %7.0f%% %8.0f%% %6.0f%%  %s\n",
+			r.Source, state, r.Latency.Round(10*time.Millisecond), r.Requests, r.Events,
+			r.MissingTime*100, r.MissingVenue*100, r.MissingCategory*100, formatStatuses(r.Statuses))
+	}
+	for _, r := range reports {
+		for _, a := range r.Anomalies {
+			fmt.Fprintf(os.Stderr, "warning: %s: %s\n", r.Source, a)
+		}
+	}
+}
+
+func formatStatuses(statuses map[int]int) string {
+	var codes []int
+	for status := range statuses {
+		codes = append(codes, status)
+	}
+	sort.Ints(codes)
+	var parts []string
+	for _, status := range codes {
+		parts = append(parts, fmt.Sprintf("%d×%d", statuses[status], status))
+	}
+	return strings.Join(parts, " ")
+}
+
+func unhealthy(reports []health.Report) int {
+	n := 0
+	for _, r := range reports {
+		if !r.OK() {
+			n++
+		}
+	}
+	return n
+}
+
+// checkRange resolves the --when of sources check and doctor.
+func checkRange() (timerange.Range, error) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	return resolveTimeRange(flagCheckWhen, time.Now().In(loc))
+}
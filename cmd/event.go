@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagEventWhen string
+	flagEventJSON bool
+)
+
+var eventCmd = &cobra.Command{
+	Use:   "event <id>",
+	Short: "Show one event by its ID",
+	Long: `Show one event by the ID printed in "leipzig events --json". IDs are
+stable between runs; any unique prefix of at least 4 characters works.
+
+Examples:
+  leipzig event 3f9a1c0b2d4e
+  leipzig event 3f9a --json
+  leipzig event 3f9a1c0b2d4e --when weekend`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEvent,
+}
+
+func init() {
+	eventCmd.Flags().StringVar(&flagEventWhen, "when", "week", "Time range to search, e.g. today, weekend, next friday, 2026-03-01..2026-03-07")
+	eventCmd.Flags().BoolVar(&flagEventJSON, "json", false, "Output as JSON")
+	eventCmd.Flags().BoolVar(&flagEnrich, "enrich", false, "Read the event's detail page")
+	rootCmd.AddCommand(eventCmd)
+}
+
+func runEvent(cmd *cobra.Command, args []string) error {
+	id := strings.ToLower(strings.TrimSpace(args[0]))
+	if len(id) < 4 {
+		return fmt.Errorf("event ID %q is too short (need at least 4 characters)", id)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	rng, err := resolveTimeRange(flagEventWhen, time.Now().In(loc))
+	if err != nil {
+		return err
+	}
+	events, err := fetchEvents(ctx, rng.From, rng.To, flagEnrich)
+	if err != nil {
+		return err
+	}
+
+	e, err := findEvent(events, id)
+	if err != nil {
+		return err
+	}
+	if flagEventJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(e)
+	}
+	return output.Detail(os.Stdout, e)
+}
+
+// findEvent returns the event whose ID is id or, failing that, the only
+// event whose ID starts with id.
+func findEvent(events []model.Event, id string) (model.Event, error) {
+	var matches []model.Event
+	for _, e := range events {
+		if e.ID == id {
+			return e, nil
+		}
+		if strings.HasPrefix(e.ID, id) {
+			matches = append(matches, e)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return model.Event{}, fmt.Errorf("no event with ID %s in --when %s", id, flagEventWhen)
+	case 1:
+		return matches[0], nil
+	default:
+		return model.Event{}, fmt.Errorf("ID prefix %s is ambiguous (%d events)", id, len(matches))
+	}
+}
@@ -4,22 +4,45 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
 	"time"
 
+	"github.com/havocked/leipzig-cli/internal/cache"
 	"github.com/havocked/leipzig-cli/internal/engine"
+	"github.com/havocked/leipzig-cli/internal/geo"
+	"github.com/havocked/leipzig-cli/internal/model"
 	"github.com/havocked/leipzig-cli/internal/output"
-	"github.com/havocked/leipzig-cli/internal/source/leipzigde"
-	"github.com/havocked/leipzig-cli/internal/source/prinzde"
+	"github.com/havocked/leipzig-cli/internal/source"
+	"github.com/havocked/leipzig-cli/internal/timerange"
 	"github.com/spf13/cobra"
 )
 
 var (
 	flagWhen     string
+	flagFrom     string
+	flagTo       string
 	flagSearch   string
 	flagCategory string
+	flagDistrict string
 	flagAfter    string
 	flagJSON     bool
+	flagMeta     bool
+	flagFormat   string
+	flagEnrich   bool
 	flagLimit    int
+
+	flagExplainDedup bool
+
+	flagNear   string
+	flagRadius string
+	flagSort   string
+
+	flagFree     bool
+	flagMaxPrice float64
+
+	flagOngoing      string
+	flagHappeningNow string
 )
 
 var eventsCmd = &cobra.Command{
@@ -27,36 +50,118 @@ var eventsCmd = &cobra.Command{
 	Short: "List events in Leipzig",
 	Long: `List events in Leipzig. Defaults to today's events.
 
+--when takes today, tomorrow, week (the next 7 days), weekend, this week,
+this month, a weekday (friday, next friday), in 3 days, next 3 days,
+easter, a date (2026-03-01 or 01.03.2026) or a range of any of these
+(2026-03-01..2026-03-07, today..sunday). German works too: heute, morgen,
+übermorgen, nächstes Wochenende, diesen Monat, Freitag, in 2 Wochen,
+Ostern. --from and --to take the same expressions and override --when.
+The resolved range is printed to stderr.
+
 Examples:
   leipzig events                          # Today's events
   leipzig events --when weekend           # This weekend
-  leipzig events --when tomorrow          # Tomorrow
+  leipzig events --when "next friday"     # One day
+  leipzig events --when "nächstes wochenende"
+  leipzig events --from 2026-02-20 --to 2026-02-22
   leipzig events --search concert         # Search by name/venue
   leipzig events --category family        # Filter by category
+  leipzig events --district Connewitz     # Ortsteil or Stadtbezirk (see: leipzig districts)
   leipzig events --after 16:00            # Events starting at 4 PM or later
+  leipzig events --free                   # Free admission only
+  leipzig events --ongoing exclude        # Without exhibitions and other multi-day events
+  leipzig events --happening-now          # In progress right now
+  leipzig events --happening-now=21:30    # In progress today at 21:30
+  leipzig events --max-price 15 --sort price
   leipzig events --json                   # JSON output for agents
-  leipzig events --search jazz --when weekend --json`,
+  leipzig events --format compact         # One line per event
+  leipzig events --enrich --json          # Include details from event pages
+  leipzig events --search jazz --when weekend --json
+
+Defaults for any flag can be set under defaults.events in
+~/.config/leipzig/config.yaml.`,
+	Args: cobra.NoArgs,
 	RunE: runEvents,
 }
 
 func init() {
-	eventsCmd.Flags().StringVar(&flagWhen, "when", "today", "Time range: today, tomorrow, weekend, week")
+	eventsCmd.Flags().StringVar(&flagWhen, "when", "today", "Time range, e.g. today, weekend, next friday, this month, 2026-03-01..2026-03-07")
+	eventsCmd.Flags().StringVar(&flagFrom, "from", "", "Start of the time range (date or --when expression; overrides --when)")
+	eventsCmd.Flags().StringVar(&flagTo, "to", "", "Last day of the time range (date or --when expression; overrides --when)")
 	eventsCmd.Flags().StringVarP(&flagSearch, "search", "s", "", "Search by name or venue")
 	eventsCmd.Flags().StringVarP(&flagCategory, "category", "c", "", "Filter by category (comma-separated)")
+	districtFlag(eventsCmd, &flagDistrict)
 	eventsCmd.Flags().StringVar(&flagAfter, "after", "", "Only events starting at or after this time (HH:MM)")
-	eventsCmd.Flags().BoolVar(&flagJSON, "json", false, "Output as JSON")
+	eventsCmd.Flags().BoolVar(&flagJSON, "json", false, "Output as JSON (same as --format json)")
+	eventsCmd.Flags().BoolVar(&flagMeta, "meta", false, "With JSON output, wrap the events in an object with the resolved time range")
+	eventsCmd.Flags().StringVarP(&flagFormat, "format", "f", "table", "Output format: table, json, compact")
 	eventsCmd.Flags().IntVarP(&flagLimit, "limit", "n", 0, "Limit number of results")
+	eventsCmd.Flags().StringVar(&flagNear, "near", "", "Only events near this address, place or lat,lon")
+	eventsCmd.Flags().StringVar(&flagRadius, "radius", "2km", "Distance from --near, e.g. 800m or 2km")
+	eventsCmd.Flags().BoolVar(&flagFree, "free", false, "Only events with free admission")
+	eventsCmd.Flags().Float64Var(&flagMaxPrice, "max-price", 0, "Only events whose cheapest regular ticket costs at most this many euros")
+	eventsCmd.Flags().StringVar(&flagOngoing, "ongoing", "include", "Multi-day events (exhibitions, festivals): include, exclude, only")
+	eventsCmd.Flags().StringVar(&flagHappeningNow, "happening-now", "", "Only events in progress now, or at HH:MM today or YYYY-MM-DD HH:MM")
+	eventsCmd.Flags().Lookup("happening-now").NoOptDefVal = "now"
+	eventsCmd.Flags().StringVar(&flagSort, "sort", "time", "Sort by: time, price, distance (needs --near)")
+	eventsCmd.Flags().BoolVar(&flagExplainDedup, "explain-dedup", false, "Print which duplicate events were merged, with their scores, to stderr")
+	eventsCmd.Flags().BoolVar(&flagEnrich, "enrich", false, "Read each event's detail page for description, address, price and end time (slow on first run, then cached)")
 	rootCmd.AddCommand(eventsCmd)
 }
 
 func runEvents(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	loc, _ := time.LoadLocation("Europe/Berlin")
 	now := time.Now().In(loc)
 
-	from, to := resolveTimeRange(flagWhen, now, loc)
+	format := flagFormat
+	if flagJSON {
+		format = "json"
+	}
+	if format != "table" && format != "json" && format != "compact" {
+		return fmt.Errorf("unknown --format %q (expected table, json or compact)", format)
+	}
+
+	if flagSort != "time" && flagSort != "price" && flagSort != "distance" {
+		return fmt.Errorf("unknown --sort %q (expected time, price or distance)", flagSort)
+	}
+	if flagSort == "distance" && flagNear == "" {
+		return fmt.Errorf("--sort distance needs --near")
+	}
+
+	if flagOngoing != "include" && flagOngoing != "exclude" && flagOngoing != "only" {
+		return fmt.Errorf("unknown --ongoing %q (expected include, exclude or only)", flagOngoing)
+	}
+
+	inDistrict, err := parseDistrict(flagDistrict)
+	if err != nil {
+		return err
+	}
+
+	rng, err := resolveTimeRange(flagWhen, now)
+	if flagFrom != "" || flagTo != "" {
+		rng, err = resolveFromTo(flagFrom, flagTo, now)
+	}
+	if err != nil {
+		return err
+	}
+	from, to := rng.From, rng.To
+	var at time.Time
+	if flagHappeningNow != "" {
+		if at, err = parseInstant(flagHappeningNow, now, loc); err != nil {
+			return err
+		}
+		// Fetch the whole day so the cache key stays stable; Filter keeps
+		// the events in progress at the instant.
+		day := timerange.Days(at, 1)
+		from, to = day.From, day.To
+	}
 
-	// Apply --after filter: shift "from" to today/tomorrow at that time
+	// --after drops events starting before that time on the first day,
+	// including ones still running and date-only ones.
+	var startAfter time.Time
 	if flagAfter != "" {
 		afterTime, err := time.ParseInLocation("15:04", flagAfter, loc)
 		if err != nil {
@@ -65,50 +170,181 @@ func runEvents(cmd *cobra.Command, args []string) error {
 		afterFull := time.Date(from.Year(), from.Month(), from.Day(),
 			afterTime.Hour(), afterTime.Minute(), 0, 0, loc)
 		if afterFull.After(from) {
-			from = afterFull
+			startAfter = afterFull
 		}
 	}
 
-	eng := engine.New(leipzigde.New(), prinzde.New())
-	events, err := eng.Fetch(ctx, from, to)
+	opts := engine.FilterOptions{
+		Category:   flagCategory,
+		District:   inDistrict,
+		Search:     flagSearch,
+		From:       from,
+		To:         to,
+		StartAfter: startAfter,
+		Free:       flagFree,
+		At:         at,
+		Ongoing:    flagOngoing,
+		Limit:      flagLimit,
+		Sort:       flagSort,
+	}
+	if cmd.Flags().Changed("max-price") {
+		if flagMaxPrice < 0 {
+			return fmt.Errorf("invalid --max-price %v", flagMaxPrice)
+		}
+		opts.MaxPrice = &flagMaxPrice
+	}
+	if flagNear != "" {
+		if opts.Radius, err = geo.ParseRadius(flagRadius); err != nil {
+			return err
+		}
+		near, err := newGeocoder(loadVenues()).Geocode(ctx, flagNear)
+		if err != nil {
+			return fmt.Errorf("--near: %w (coordinates such as 51.3119,12.3803 always work)", err)
+		}
+		opts.Near = near.Point
+	}
+
+	events, err := fetchEvents(ctx, from, to, flagEnrich)
 	if err != nil {
-		return fmt.Errorf("fetch events: %w", err)
+		return err
 	}
 
-	filtered := engine.Filter(events, engine.FilterOptions{
-		Category: flagCategory,
-		Search:   flagSearch,
-		From:     from,
-		To:       to,
-		Limit:    flagLimit,
-	})
+	filtered := engine.Filter(events, opts)
+	rng = timerange.Range{From: from, To: to}
+	fmt.Fprintf(os.Stderr, "%s: %d found\n", rng, len(filtered))
 
-	if flagJSON {
+	switch format {
+	case "json":
+		if flagMeta {
+			return output.JSONMeta(os.Stdout, output.Meta{Range: rng, Count: len(filtered)}, filtered)
+		}
 		return output.JSON(os.Stdout, filtered)
+	case "compact":
+		return output.Compact(os.Stdout, filtered)
+	default:
+		return output.Table(os.Stdout, filtered)
 	}
-	return output.Table(os.Stdout, filtered)
 }
 
-func resolveTimeRange(when string, now time.Time, loc *time.Location) (from, to time.Time) {
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
-
-	switch when {
-	case "tomorrow":
-		from = today.Add(24 * time.Hour)
-		to = from.Add(24 * time.Hour)
-	case "weekend":
-		daysUntilSat := (6 - int(now.Weekday()) + 7) % 7
-		if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
-			daysUntilSat = 0
+// fetchEvents fetches, merges and dedups events from all enabled sources
+// through the cache.
+func fetchEvents(ctx context.Context, from, to time.Time, enrich bool) ([]model.Event, error) {
+	store := openCache()
+	var sources []source.Source
+	for _, src := range eventSources() {
+		sources = append(sources, cache.Wrap(src, store, cfg.TTL(src.ID(), eventsTTL)))
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no event sources enabled (see: leipzig sources --enable)")
+	}
+
+	eng := newEngine(sources)
+	eng.Enrich = enrich
+	if flagExplainDedup {
+		eng.Explain = explainMatch
+	}
+	events, err := eng.Fetch(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("fetch events: %w", err)
+	}
+	return events, nil
+}
+
+// explainMatch prints one dedup merge to stderr.
+func explainMatch(m engine.Match) {
+	fmt.Fprintf(os.Stderr, "merged %.2f (name %.2f, venue %.2f, time %.2f)\n", m.Score, m.Name, m.Venue, m.Time)
+	for _, e := range []model.Event{m.Kept, m.Merged} {
+		var sources []string
+		for _, ref := range e.Sources {
+			sources = append(sources, ref.Source)
 		}
-		from = today.Add(time.Duration(daysUntilSat) * 24 * time.Hour)
-		to = from.Add(48 * time.Hour)
-	case "week":
-		from = today
-		to = today.Add(7 * 24 * time.Hour)
-	default: // "today"
-		from = today
-		to = today.Add(24 * time.Hour)
-	}
-	return
+		fmt.Fprintf(os.Stderr, "  %-22s %s  %s @ %s\n",
+			strings.Join(sources, ","), e.StartTime.Format("Mon 02 Jan 15:04"), e.Name, e.Venue)
+	}
+}
+
+// newEngine builds an engine configured from the fetch settings.
+func newEngine(sources []source.Source) *engine.Engine {
+	eng := engine.New(sources...)
+	if cfg.Fetch.Concurrency > 0 {
+		eng.Concurrency = cfg.Fetch.Concurrency
+	}
+	if len(cfg.Merge.Priority) > 0 {
+		eng.Priority.Sources = cfg.Merge.Priority
+	}
+	if len(cfg.Merge.Fields) > 0 {
+		eng.Priority.Fields = cfg.Merge.Fields
+		if err := eng.Priority.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: config: %v\n", err)
+		}
+	}
+	eng.Venues = loadVenues()
+	eng.Places = newGeocoder(eng.Venues)
+	eng.Timeouts = make(map[string]time.Duration)
+	for _, src := range sources {
+		eng.Timeouts[src.ID()] = sourceTimeout(src.ID())
+	}
+	return eng
+}
+
+// sourceTimeout returns how long one fetch from the source may take: its
+// own timeout, else fetch.timeout, else the engine default.
+func sourceTimeout(id string) time.Duration {
+	if t := cfg.Source(id).Timeout; t > 0 {
+		return t
+	}
+	if cfg.Fetch.Timeout > 0 {
+		return cfg.Fetch.Timeout
+	}
+	return engine.DefaultSourceTimeout
+}
+
+// parseInstant parses a --happening-now value: "now", "HH:MM" (today) or
+// "YYYY-MM-DD HH:MM".
+func parseInstant(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	if s == "now" {
+		return now, nil
+	}
+	if t, err := time.ParseInLocation("15:04", s, loc); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, loc), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --happening-now %q (expected now, HH:MM or YYYY-MM-DD HH:MM)", s)
+}
+
+// resolveTimeRange resolves a --when expression (see timerange.Parse).
+func resolveTimeRange(when string, now time.Time) (timerange.Range, error) {
+	r, err := timerange.Parse(when, now)
+	if err != nil {
+		return r, fmt.Errorf("invalid --when: %w (try today, weekend, next friday or 2026-03-01..2026-03-07)", err)
+	}
+	return r, nil
+}
+
+// resolveFromTo resolves --from and --to: from the start of --from (default
+// today) to the end of --to (default a week after --from).
+func resolveFromTo(from, to string, now time.Time) (timerange.Range, error) {
+	r := timerange.Days(now, 7)
+	if from != "" {
+		f, err := timerange.Parse(from, now)
+		if err != nil {
+			return r, fmt.Errorf("invalid --from: %w", err)
+		}
+		r = timerange.Days(f.From, 7)
+	}
+	if to != "" {
+		t, err := timerange.Parse(to, now)
+		if err != nil {
+			return r, fmt.Errorf("invalid --to: %w", err)
+		}
+		r.To = t.To
+	}
+	if !r.To.After(r.From) {
+		return r, fmt.Errorf("--to %q is before --from %q", to, from)
+	}
+	return r, nil
 }
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/havocked/leipzig-cli/internal/config"
+	"github.com/havocked/leipzig-cli/internal/geo"
+	"github.com/havocked/leipzig-cli/internal/httpx"
+	"github.com/havocked/leipzig-cli/internal/venue"
+	"github.com/spf13/cobra"
+)
+
+// addressesFile is the imported address dataset in the config directory.
+const addressesFile = "addresses.csv"
+
+var flagGeoJSON bool
+
+var geoCmd = &cobra.Command{
+	Use:   "geo",
+	Short: "Import address data and look up places offline",
+	Long: `Resolve Leipzig addresses and venue names to coordinates and Ortsteile
+without going online. Built in are the venues and a list of squares and
+streets; import an address dataset for house-level accuracy.`,
+}
+
+var geoImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a Leipzig address dataset (CSV or OSM extract)",
+	Long: `Import addresses into ~/.config/leipzig/addresses.csv, replacing any
+earlier import.
+
+The file is either an OpenStreetMap extract (.osm or .xml; nodes with
+addr:street tags) or a CSV with a header row naming the street, house
+number, postcode, Ortsteil and lat/lon columns, in English or German
+(Straße, Hausnummer, PLZ, Ortsteil, lat, lon). Comma or semicolon
+separators and decimal commas are fine.
+
+Examples:
+  leipzig geo import leipzig-latest.osm
+  leipzig geo import adressen.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGeoImport,
+}
+
+var geoLookupCmd = &cobra.Command{
+	Use:   "lookup <address or place>",
+	Short: "Resolve an address or place to coordinates and map links",
+	Long: `Resolve an address, venue or place offline and print its coordinates,
+Ortsteil and Google Maps, OpenStreetMap and geo: links. Street names may
+be misspelled slightly ("Karl Liebknechtstrasse 46").
+
+Examples:
+  leipzig geo lookup "Karl-Liebknecht-Str. 46"
+  leipzig geo lookup gewandhaus --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runGeoLookup,
+}
+
+func init() {
+	geoLookupCmd.Flags().BoolVar(&flagGeoJSON, "json", false, "Output as JSON")
+	geoCmd.AddCommand(geoImportCmd, geoLookupCmd)
+	rootCmd.AddCommand(geoCmd)
+}
+
+func runGeoImport(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(args[0]); err != nil {
+		return err
+	}
+	addrs, err := geo.LoadAddresses(args[0])
+	if err != nil {
+		return err
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("no addresses with coordinates in %s", args[0])
+	}
+
+	dir, err := config.Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, addressesFile)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := geo.WriteAddresses(f, addrs); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Imported %d addresses into %s\n", len(addrs), path)
+	return nil
+}
+
+func runGeoLookup(cmd *cobra.Command, args []string) error {
+	query := args[0]
+	for _, a := range args[1:] {
+		query += " " + a
+	}
+	p, ok := newGeocoder(loadVenues()).Lookup(query)
+	if !ok {
+		return fmt.Errorf("%w: %q (try the full street name, or import addresses: leipzig geo import)", geo.ErrNotFound, query)
+	}
+	links := geo.MapLinks(p.Point, "")
+
+	if flagGeoJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			geo.Place
+			geo.Links
+		}{p, links})
+	}
+
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Printf("%-12s %s\n", label+":", value)
+		}
+	}
+	field("Name", p.Name)
+	field("Match", p.Kind)
+	field("Ortsteil", p.Ortsteil)
+	field("Coordinates", fmt.Sprintf("%.5f, %.5f", p.Lat, p.Lon))
+	field("Google Maps", links.Google)
+	field("OSM", links.OSM)
+	field("geo: URI", links.GeoURI)
+	return nil
+}
+
+// newGeocoder returns a geocoder that knows the built-in places, every
+// venue's names and address and the imported addresses, caches online
+// lookups and, with --no-cache, doesn't remember them.
+func newGeocoder(venues *venue.Registry) *geo.Geocoder {
+	g := geo.NewGazetteer()
+	if dir, err := config.Dir(); err == nil {
+		addrs, err := geo.LoadAddresses(filepath.Join(dir, addressesFile))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		}
+		g.AddAddresses(addrs...)
+	}
+	for _, v := range venues.All() {
+		if !v.HasCoords() {
+			continue
+		}
+		p := geo.Point{Lat: v.Lat, Lon: v.Lon}
+		g.Add(geo.Place{Name: v.Name, Kind: "place", Ortsteil: v.Ortsteil, Point: p}, append([]string{v.ID}, v.Aliases...)...)
+		if v.Address != "" {
+			g.Add(geo.Place{Name: v.Address, Kind: "address", Ortsteil: v.Ortsteil, Point: p})
+		}
+	}
+	return geo.NewGeocoder(g, openCache(), httpx.Default())
+}
+
+// mapLinks returns map links for the first of queries (addresses or place
+// names) the geocoder resolves offline to an exact point, else search links
+// for search. Street midpoints are too rough to pin.
+func mapLinks(g *geo.Geocoder, search string, queries ...string) (geo.Point, geo.Links) {
+	for _, q := range queries {
+		if q == "" {
+			continue
+		}
+		if p, ok := g.Lookup(q); ok && p.Kind != "street" {
+			return p.Point, geo.MapLinks(p.Point, search)
+		}
+	}
+	return geo.Point{}, geo.MapLinks(geo.Point{}, search)
+}
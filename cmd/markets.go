@@ -7,13 +7,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/havocked/leipzig-cli/internal/district"
 	"github.com/havocked/leipzig-cli/internal/market"
 	"github.com/spf13/cobra"
 )
 
 var (
-	marketsDay  string
-	marketsJSON bool
+	marketsDay      string
+	marketsDistrict string
+	marketsJSON     bool
 )
 
 var marketsCmd = &cobra.Command{
@@ -25,6 +27,7 @@ var marketsCmd = &cobra.Command{
 
 func init() {
 	marketsCmd.Flags().StringVar(&marketsDay, "day", "today", "Filter by day: today, tomorrow, monday-sunday, or all")
+	districtFlag(marketsCmd, &marketsDistrict)
 	marketsCmd.Flags().BoolVar(&marketsJSON, "json", false, "JSON output")
 	rootCmd.AddCommand(marketsCmd)
 }
@@ -72,12 +75,17 @@ func runMarkets(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	inDistrict, err := parseDistrict(marketsDistrict)
+	if err != nil {
+		return err
+	}
 
 	if all {
-		return printAll()
+		return printAll(inDistrict)
 	}
 
-	markets := market.ForDay(day)
+	markets := inMarketDistrict(market.ForDay(day), inDistrict)
+	locateMarkets(markets)
 
 	if marketsJSON {
 		enc := json.NewEncoder(os.Stdout)
@@ -98,14 +106,22 @@ func runMarkets(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func printAll() error {
+func printAll(inDistrict string) error {
 	allDays := market.AllByDay()
+	for d, list := range allDays {
+		if list = inMarketDistrict(list, inDistrict); len(list) > 0 {
+			allDays[d] = list
+		} else {
+			delete(allDays, d)
+		}
+	}
 	order := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
 
 	if marketsJSON {
 		out := map[string][]market.MarketDay{}
 		for _, d := range order {
 			if list, ok := allDays[d]; ok {
+				locateMarkets(list)
 				out[d.String()] = list
 			}
 		}
@@ -114,7 +130,7 @@ func printAll() error {
 		return enc.Encode(out)
 	}
 
-	fmt.Println("Leipzig Weekly Markets (Wochenmärkte):\n")
+	fmt.Print("Leipzig Weekly Markets (Wochenmärkte):\n\n")
 	for _, d := range order {
 		list, ok := allDays[d]
 		if !ok {
@@ -128,3 +144,29 @@ func printAll() error {
 	}
 	return nil
 }
+
+// inMarketDistrict returns the markets in the given district ("" for all).
+func inMarketDistrict(markets []market.MarketDay, d string) []market.MarketDay {
+	if d == "" {
+		return markets
+	}
+	var result []market.MarketDay
+	for _, m := range markets {
+		if district.Within(m.Ortsteil, d) {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// locateMarkets sets the markets' map links, with coordinates where the
+// market's place is known.
+func locateMarkets(markets []market.MarketDay) {
+	g := newGeocoder(loadVenues())
+	for i, m := range markets {
+		name := strings.TrimSuffix(m.Name, " (private)")
+		pt, links := mapLinks(g, name, name)
+		markets[i].Lat, markets[i].Lon = pt.Lat, pt.Lon
+		markets[i].MapURL, markets[i].OSMURL, markets[i].GeoURI = links.Google, links.OSM, links.GeoURI
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/havocked/leipzig-cli/internal/cache"
 	"github.com/havocked/leipzig-cli/internal/news"
 	"github.com/spf13/cobra"
 )
@@ -58,7 +59,9 @@ func runNews(cmd *cobra.Command, args []string) error {
 
 	fmt.Fprintf(os.Stderr, "Fetching news from leipzig.de...\n")
 
-	articles, err := news.Fetch(opts)
+	articles, err := cache.Load(openCache(), newsCacheKey(opts), "news", ttlFor("news"), func() ([]news.Article, error) {
+		return news.Fetch(opts)
+	})
 	if err != nil {
 		return fmt.Errorf("fetching news: %w", err)
 	}
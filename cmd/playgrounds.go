@@ -6,6 +6,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/havocked/leipzig-cli/internal/cache"
+	"github.com/havocked/leipzig-cli/internal/district"
 	"github.com/havocked/leipzig-cli/internal/playground"
 	"github.com/spf13/cobra"
 )
@@ -25,7 +27,7 @@ var playgroundsCmd = &cobra.Command{
 }
 
 func init() {
-	playgroundsCmd.Flags().StringVarP(&pgDistrict, "district", "d", "", "Filter by district/subdistrict (case-insensitive contains)")
+	districtFlag(playgroundsCmd, &pgDistrict)
 	playgroundsCmd.Flags().StringVarP(&pgSearch, "search", "s", "", "Search by name (case-insensitive contains)")
 	playgroundsCmd.Flags().BoolVar(&pgJSON, "json", false, "JSON output")
 	playgroundsCmd.Flags().IntVarP(&pgLimit, "limit", "n", 0, "Max results (0=all)")
@@ -33,9 +35,14 @@ func init() {
 }
 
 func runPlaygrounds(cmd *cobra.Command, args []string) error {
+	inDistrict, err := parseDistrict(pgDistrict)
+	if err != nil {
+		return err
+	}
+
 	fmt.Fprintf(os.Stderr, "Fetching playgrounds from leipzig.de...\n")
 
-	all, err := playground.FetchAll()
+	all, err := cache.Load(openCache(), playgroundsCacheKey, "playgrounds", ttlFor("playgrounds"), playground.FetchAll)
 	if err != nil {
 		return fmt.Errorf("fetching playgrounds: %w", err)
 	}
@@ -43,12 +50,8 @@ func runPlaygrounds(cmd *cobra.Command, args []string) error {
 	// Filter
 	var results []playground.Playground
 	for _, p := range all {
-		if pgDistrict != "" {
-			d := strings.ToLower(pgDistrict)
-			if !strings.Contains(strings.ToLower(p.District), d) &&
-				!strings.Contains(strings.ToLower(p.Subdistrict), d) {
-				continue
-			}
+		if inDistrict != "" && !district.Within(playgroundDistrict(p), inDistrict) {
+			continue
 		}
 		if pgSearch != "" {
 			if !strings.Contains(strings.ToLower(p.Name), strings.ToLower(pgSearch)) {
@@ -63,6 +66,16 @@ func runPlaygrounds(cmd *cobra.Command, args []string) error {
 		results = results[:pgLimit]
 	}
 
+	g := newGeocoder(loadVenues())
+	for i, p := range results {
+		if p.Address == "" {
+			continue
+		}
+		pt, links := mapLinks(g, p.Address, p.Address)
+		results[i].Lat, results[i].Lon = pt.Lat, pt.Lon
+		results[i].MapURL, results[i].OSMURL, results[i].GeoURI = links.Google, links.OSM, links.GeoURI
+	}
+
 	if pgJSON {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
@@ -1,15 +1,34 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/havocked/leipzig-cli/internal/cache"
+	"github.com/havocked/leipzig-cli/internal/config"
+	"github.com/havocked/leipzig-cli/internal/httpx"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	flagNoCache bool
+
+	// cfg is loaded before any command runs.
+	cfg = &config.Config{}
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "leipzig",
-	Short: "Discover events and activities in Leipzig",
-	Long:  "A CLI tool for discovering events in Leipzig from multiple sources.",
+	Use:               "leipzig",
+	Short:             "Discover events and activities in Leipzig",
+	Long:              "A CLI tool for discovering events in Leipzig from multiple sources.",
+	PersistentPreRunE: loadConfig,
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&flagNoCache, "no-cache", false, "Bypass the on-disk cache and fetch fresh data")
 }
 
 func Execute() {
@@ -17,3 +36,67 @@ func Execute() {
 		os.Exit(1)
 	}
 }
+
+// loadConfig reads the config file and fills in any flag the user didn't set
+// from the command's configured defaults.
+func loadConfig(cmd *cobra.Command, args []string) error {
+	c, err := config.Load()
+	if err != nil {
+		return err
+	}
+	cfg = c
+	configureHTTP()
+	warnUnknownSources()
+
+	name := commandKey(cmd)
+	var setErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed || setErr != nil {
+			return
+		}
+		if v, ok := cfg.Default(name, f.Name); ok {
+			setErr = cmd.Flags().Set(f.Name, v)
+		}
+	})
+	return setErr
+}
+
+// configureHTTP sets up the shared HTTP client from the config. With
+// --no-cache, responses are not stored for conditional requests.
+func configureHTTP() {
+	opts := httpx.Options{
+		Interval:   cfg.Fetch.Interval,
+		MaxRetries: cfg.Fetch.MaxRetries,
+	}
+	if !flagNoCache {
+		dir := cfg.CacheDir()
+		if dir == "" {
+			dir, _ = cache.DefaultDir()
+		}
+		if dir != "" {
+			opts.CacheDir = filepath.Join(dir, "http")
+		}
+	}
+	httpx.SetDefault(httpx.NewClient(opts))
+}
+
+// warnUnknownSources flags config sections for sources that don't exist,
+// usually a typo in the source ID.
+func warnUnknownSources() {
+	if len(cfg.UnknownSources()) == 0 {
+		return
+	}
+	var extra []string
+	for _, src := range runtimeSources() {
+		extra = append(extra, src.ID())
+	}
+	for _, id := range cfg.UnknownSources(extra...) {
+		fmt.Fprintf(os.Stderr, "warning: config: unknown source %q\n", id)
+	}
+}
+
+// commandKey returns the config key for a command, e.g. "events" or "cache.warm".
+func commandKey(cmd *cobra.Command) string {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	return strings.ReplaceAll(path, " ", ".")
+}
@@ -1,30 +1,286 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
-	"github.com/havocked/leipzig-cli/internal/source/leipzigde"
-	"github.com/havocked/leipzig-cli/internal/source/prinzde"
+	"github.com/havocked/leipzig-cli/internal/config"
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/output"
+	"github.com/havocked/leipzig-cli/internal/source"
+	_ "github.com/havocked/leipzig-cli/internal/source/all"
+	"github.com/havocked/leipzig-cli/internal/source/plugin"
+	"github.com/havocked/leipzig-cli/internal/source/selector"
 	"github.com/spf13/cobra"
 )
 
+var (
+	flagEnable  []string
+	flagDisable []string
+
+	flagSourcesJSON bool
+
+	flagTestHTML string
+	flagTestJSON bool
+)
+
 var sourcesCmd = &cobra.Command{
 	Use:   "sources",
 	Short: "List available event sources",
-	Run: func(cmd *cobra.Command, args []string) {
-		sources := []struct {
-			id, status, desc string
-		}{
-			{leipzigde.New().ID(), "enabled", "City of Leipzig official event calendar"},
-			{prinzde.New().ID(), "enabled", "prinz.de Leipzig event listings"},
-		}
-		fmt.Printf("%-15s %-10s %s\n", "SOURCE", "STATUS", "DESCRIPTION")
-		for _, s := range sources {
-			fmt.Printf("%-15s %-10s %s\n", s.id, s.status, s.desc)
-		}
-	},
+	Long: `List available event sources and enable or disable them.
+
+Changes made with --enable/--disable are saved to the config file. Selector
+sources are YAML definitions in ~/.config/leipzig/sources.d/; plugin sources
+are executables named leipzig-source-<id> on $PATH.
+
+Examples:
+  leipzig sources                         # List sources and their status
+  leipzig sources --disable prinz.de      # Stop using prinz.de
+  leipzig sources --enable prinz.de       # Use it again`,
+	RunE: runSources,
+}
+
+var sourcesTestCmd = &cobra.Command{
+	Use:   "test <definition>",
+	Short: "Run a selector source definition and print the parsed events",
+	Long: `Run a selector source definition (a YAML file, or the ID of one in
+sources.d) and print the events it parses.
+
+Examples:
+  leipzig sources test werk2                          # Fetch live
+  leipzig sources test ./werk2.yaml --html page.html  # Parse a saved page`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSourcesTest,
 }
 
 func init() {
+	sourcesCmd.Flags().StringSliceVar(&flagEnable, "enable", nil, "Enable sources (comma-separated) and save to config")
+	sourcesCmd.Flags().StringSliceVar(&flagDisable, "disable", nil, "Disable sources (comma-separated) and save to config")
+	sourcesCmd.Flags().BoolVar(&flagSourcesJSON, "json", false, "Output as JSON, including homepage, horizon and categories")
+	sourcesTestCmd.Flags().StringVar(&flagTestHTML, "html", "", "Parse this HTML file instead of fetching the definition's URL")
+	sourcesTestCmd.Flags().BoolVar(&flagTestJSON, "json", false, "Output as JSON")
+	sourcesCmd.AddCommand(sourcesTestCmd)
 	rootCmd.AddCommand(sourcesCmd)
 }
+
+func runSources(cmd *cobra.Command, args []string) error {
+	extra := runtimeSources()
+
+	if len(flagEnable) > 0 || len(flagDisable) > 0 {
+		for _, id := range flagEnable {
+			if !isKnownSource(id, extra) {
+				return fmt.Errorf("unknown source %q", id)
+			}
+			cfg.SetSourceEnabled(id, true)
+		}
+		for _, id := range flagDisable {
+			if !isKnownSource(id, extra) {
+				return fmt.Errorf("unknown source %q", id)
+			}
+			cfg.SetSourceEnabled(id, false)
+		}
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Saved %s\n", cfg.File())
+	}
+
+	type sourceInfo struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+		source.Description
+		HorizonDays int `json:"horizonDays,omitempty"`
+	}
+	var infos []sourceInfo
+	for _, id := range source.IDs() {
+		status := "enabled"
+		_, err := source.New(id, cfg.Source(id).Adapter())
+		switch {
+		case !cfg.SourceEnabled(id):
+			status = "disabled"
+		case err != nil:
+			status = "unconfigured"
+		}
+		d, _ := source.Describe(id)
+		infos = append(infos, sourceInfo{id, status, d, int(d.Horizon.Hours() / 24)})
+	}
+	for _, src := range extra {
+		status := "enabled"
+		if !cfg.SourceEnabled(src.ID()) {
+			status = "disabled"
+		}
+		d, _ := source.DescriptionOf(src)
+		infos = append(infos, sourceInfo{src.ID(), status, d, int(d.Horizon.Hours() / 24)})
+	}
+
+	if flagSourcesJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(infos)
+	}
+	fmt.Printf("%-15s %-13s %s\n", "SOURCE", "STATUS", "DESCRIPTION")
+	for _, s := range infos {
+		fmt.Printf("%-15s %-13s %s\n", s.ID, s.Status, s.Text)
+	}
+	return nil
+}
+
+func runSourcesTest(cmd *cobra.Command, args []string) error {
+	def, err := findSelectorDef(args[0])
+	if err != nil {
+		return err
+	}
+
+	var events []model.Event
+	if flagTestHTML != "" {
+		f, err := os.Open(flagTestHTML)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		var next string
+		events, next, err = def.Parse(f, def.URL, time.Now())
+		if err != nil {
+			return err
+		}
+		if next != "" {
+			fmt.Fprintf(os.Stderr, "Next page: %s\n", next)
+		}
+	} else {
+		events, err = selector.New(def).Fetch(context.Background(), time.Time{}, time.Time{})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Parsed %d events with %s\n", len(events), def.Path())
+	if flagTestJSON {
+		return output.JSON(os.Stdout, events)
+	}
+	return output.Table(os.Stdout, events)
+}
+
+// eventSources returns the enabled, uncached event sources.
+func eventSources() []source.Source {
+	var sources []source.Source
+	for _, id := range source.IDs() {
+		if !cfg.SourceEnabled(id) {
+			continue
+		}
+		src, err := source.New(id, cfg.Source(id).Adapter())
+		switch {
+		case errors.Is(err, source.ErrNotConfigured):
+			continue
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			continue
+		}
+		sources = append(sources, src)
+	}
+	for _, src := range runtimeSources() {
+		if cfg.SourceEnabled(src.ID()) {
+			sources = append(sources, src)
+		}
+	}
+	return sources
+}
+
+// newSource returns the uncached source with the given ID, enabled or not,
+// or nil if it is unknown or unconfigured. extra is runtimeSources().
+func newSource(id string, extra []source.Source) source.Source {
+	if _, ok := source.Lookup(id); ok {
+		src, _ := source.New(id, cfg.Source(id).Adapter())
+		return src
+	}
+	for _, src := range extra {
+		if src.ID() == id {
+			return src
+		}
+	}
+	return nil
+}
+
+// runtimeSources returns the sources found at run time rather than
+// registered: selector definitions in sources.d, then leipzig-source-*
+// plugins on $PATH. A plugin can't shadow another source's ID.
+func runtimeSources() []source.Source {
+	var sources []source.Source
+	taken := make(map[string]bool)
+	for _, id := range source.IDs() {
+		taken[id] = true
+	}
+	for _, d := range selectorDefs() {
+		sources = append(sources, selector.New(d))
+		taken[d.ID] = true
+	}
+
+	plugins := plugin.Discover()
+	ids := make([]string, 0, len(plugins))
+	for id := range plugins {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if taken[id] {
+			fmt.Fprintf(os.Stderr, "warning: ignoring plugin %s: source %q already exists\n", plugins[id], id)
+			continue
+		}
+		sources = append(sources, plugin.New(id, plugins[id], cfg.Source(id).Adapter()))
+	}
+	return sources
+}
+
+// selectorDefs loads the selector source definitions from sources.d.
+func selectorDefs() []*selector.Definition {
+	dir, err := selectorDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return nil
+	}
+	defs, err := selector.LoadDir(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	return defs
+}
+
+func selectorDir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sources.d"), nil
+}
+
+// findSelectorDef resolves a definition file path or the ID of an installed one.
+func findSelectorDef(arg string) (*selector.Definition, error) {
+	if strings.HasSuffix(arg, ".yaml") || strings.HasSuffix(arg, ".yml") || strings.ContainsRune(arg, os.PathSeparator) {
+		return selector.LoadFile(arg)
+	}
+	for _, d := range selectorDefs() {
+		if d.ID == arg {
+			return d, nil
+		}
+	}
+	dir, _ := selectorDir()
+	return nil, fmt.Errorf("no selector definition %q in %s", arg, dir)
+}
+
+func isKnownSource(id string, extra []source.Source) bool {
+	if _, ok := source.Lookup(id); ok {
+		return true
+	}
+	for _, src := range extra {
+		if src.ID() == id {
+			return true
+		}
+	}
+	return false
+}
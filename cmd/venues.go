@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/config"
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/output"
+	"github.com/havocked/leipzig-cli/internal/venue"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagVenuesWhen string
+	flagVenuesJSON bool
+)
+
+var venuesCmd = &cobra.Command{
+	Use:   "venues [venue]",
+	Short: "List known venues and their upcoming events",
+	Long: `List the venues events are matched to, with the number of upcoming
+events at each. Given a venue ID or name, show its details and events.
+
+Add or correct venues in ~/.config/leipzig/venues.yaml:
+
+  venues:
+    - id: frau-krause
+      name: Frau Krause
+      aliases: [Kneipe Frau Krause]
+      address: Simildenstraße 8, 04277 Leipzig
+      ortsteil: Connewitz
+      stadtbezirk: Süd
+      lat: 51.3172
+      lon: 12.3837
+
+Examples:
+  leipzig venues                  # Venues by number of events this week
+  leipzig venues --when weekend
+  leipzig venues gewandhaus       # Details and upcoming events`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runVenues,
+}
+
+func init() {
+	venuesCmd.Flags().StringVar(&flagVenuesWhen, "when", "week", "Time range to count events in, e.g. today, weekend, next friday, 2026-03-01..2026-03-07")
+	venuesCmd.Flags().BoolVar(&flagVenuesJSON, "json", false, "Output as JSON")
+	rootCmd.AddCommand(venuesCmd)
+}
+
+func runVenues(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	venues := loadVenues()
+	var only *venue.Venue
+	if len(args) == 1 {
+		v, ok := venues.Get(args[0])
+		if !ok {
+			v, ok = venues.Match(args[0])
+		}
+		if !ok {
+			return fmt.Errorf("unknown venue %q (see: leipzig venues)", args[0])
+		}
+		only = &v
+	}
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	rng, err := resolveTimeRange(flagVenuesWhen, time.Now().In(loc))
+	if err != nil {
+		return err
+	}
+	events, err := fetchEvents(ctx, rng.From, rng.To, false)
+	if err != nil {
+		return err
+	}
+	byVenue := make(map[string][]model.Event)
+	for _, e := range events {
+		if e.VenueID != "" {
+			byVenue[e.VenueID] = append(byVenue[e.VenueID], e)
+		}
+	}
+
+	if only != nil {
+		return venueDetail(*only, byVenue[only.ID])
+	}
+
+	type venueInfo struct {
+		venue.Venue
+		UpcomingEvents int `json:"upcomingEvents"`
+	}
+	var infos []venueInfo
+	for _, v := range venues.All() {
+		infos = append(infos, venueInfo{v, len(byVenue[v.ID])})
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].UpcomingEvents > infos[j].UpcomingEvents
+	})
+
+	if flagVenuesJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(infos)
+	}
+	fmt.Printf("%-26s %-30s %-18s %s\n", "ID", "NAME", "ORTSTEIL", "EVENTS")
+	for _, v := range infos {
+		fmt.Printf("%-26s %-30s %-18s %d\n", v.ID, v.Name, v.Ortsteil, v.UpcomingEvents)
+	}
+	return nil
+}
+
+func venueDetail(v venue.Venue, events []model.Event) error {
+	if flagVenuesJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			venue.Venue
+			Events []model.Event `json:"events"`
+		}{v, events})
+	}
+
+	fmt.Printf("%s\n\n", v.Name)
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Printf("%-14s %s\n", label+":", value)
+		}
+	}
+	field("ID", v.ID)
+	field("Address", v.Address)
+	if v.Stadtbezirk != "" {
+		field("District", v.Ortsteil+" ("+v.Stadtbezirk+")")
+	} else {
+		field("District", v.Ortsteil)
+	}
+	if v.HasCoords() {
+		field("Coordinates", fmt.Sprintf("%.5f, %.5f", v.Lat, v.Lon))
+	}
+	field("Accessibility", v.Accessibility)
+	field("Homepage", v.Homepage)
+	fmt.Println()
+	return output.Table(os.Stdout, events)
+}
+
+// loadVenues returns the built-in venues plus the user's venues.yaml.
+func loadVenues() *venue.Registry {
+	dir, err := config.Dir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return venue.Default()
+	}
+	r, err := venue.Load(filepath.Join(dir, "venues.yaml"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	return r
+}
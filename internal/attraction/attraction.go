@@ -12,6 +12,11 @@ type Attraction struct {
 	Address     string `json:"address,omitempty"`
 	URL         string `json:"url,omitempty"`
 	MapURL      string `json:"map_url"`
+	// Lat, Lon, OSMURL and GeoURI are set when the place is resolved.
+	Lat    float64 `json:"lat,omitempty"`
+	Lon    float64 `json:"lon,omitempty"`
+	OSMURL string  `json:"osm_url,omitempty"`
+	GeoURI string  `json:"geo_uri,omitempty"`
 }
 
 func makeMapURL(address string) string {
@@ -0,0 +1,182 @@
+// Package cache is a small on-disk cache for scraped data. Each entry is a
+// JSON file keyed by a hash of its cache key; freshness is decided by the
+// caller's TTL so the same entry can be served stale when a source is down.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTTL is used when no TTL is configured for a source.
+const DefaultTTL = time.Hour
+
+// Store is a directory of cache entries.
+type Store struct {
+	dir string
+	now func() time.Time
+}
+
+// Entry describes one cached item.
+type Entry struct {
+	Key      string          `json:"key"`
+	Source   string          `json:"source"`
+	StoredAt time.Time       `json:"storedAt"`
+	Data     json.RawMessage `json:"data"`
+
+	path string
+	size int64
+}
+
+// Size returns the on-disk size of the entry in bytes.
+func (e Entry) Size() int64 { return e.size }
+
+// Age returns how long ago the entry was stored.
+func (e Entry) Age(now time.Time) time.Duration { return now.Sub(e.StoredAt) }
+
+// DefaultDir returns the cache directory, usually ~/.cache/leipzig.
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cache: locate user cache dir: %w", err)
+	}
+	return filepath.Join(base, "leipzig"), nil
+}
+
+// Open returns a store rooted at dir, creating the directory if needed.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cache: create %s: %w", dir, err)
+	}
+	return &Store{dir: dir, now: time.Now}, nil
+}
+
+// Dir returns the directory backing the store.
+func (s *Store) Dir() string { return s.dir }
+
+// Get loads the entry for key. ok is false if nothing is cached.
+func (s *Store) Get(key string) (e Entry, ok bool, err error) {
+	p := s.path(key)
+	data, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("cache: read %s: %w", key, err)
+	}
+	if err := json.Unmarshal(data, &e); err != nil {
+		return Entry{}, false, fmt.Errorf("cache: decode %s: %w", key, err)
+	}
+	e.path = p
+	e.size = int64(len(data))
+	return e, true, nil
+}
+
+// Put stores v under key. source is recorded for status output and for
+// clearing a single source's entries.
+func (s *Store) Put(key, source string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("cache: encode %s: %w", key, err)
+	}
+	data, err := json.Marshal(Entry{Key: key, Source: source, StoredAt: s.now(), Data: raw})
+	if err != nil {
+		return fmt.Errorf("cache: encode %s: %w", key, err)
+	}
+
+	// Write to a temp file and rename so concurrent readers never see a
+	// half-written entry.
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("cache: write %s: %w", key, err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("cache: write %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("cache: write %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("cache: write %s: %w", key, err)
+	}
+	return nil
+}
+
+// Entries lists all cached entries, sorted by source then key.
+func (s *Store) Entries() ([]Entry, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			continue
+		}
+		e.path = f
+		e.size = int64(len(data))
+		e.Data = nil
+		entries = append(entries, e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Source != entries[j].Source {
+			return entries[i].Source < entries[j].Source
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries, nil
+}
+
+// Clear removes cached entries. With no sources given everything is removed,
+// otherwise only entries belonging to one of the named sources.
+func (s *Store) Clear(sources ...string) (int, error) {
+	entries, err := s.Entries()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if len(sources) > 0 && !contains(sources, e.Source) {
+			continue
+		}
+		if err := os.Remove(e.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, fmt.Errorf("cache: remove %s: %w", e.Key, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+// testStore returns a store in a temp dir whose clock the test controls.
+func testStore(t *testing.T) (*Store, *time.Time) {
+	t.Helper()
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestLoad(t *testing.T) {
+	errDown := errors.New("site down")
+	tests := []struct {
+		name     string
+		cached   bool          // "old" is stored before the call
+		age      time.Duration // how long before the call it was stored
+		fetchErr error
+		want     string
+		wantErr  bool
+		fetched  bool
+	}{
+		{name: "empty cache", want: "new", fetched: true},
+		{name: "fresh hit", cached: true, age: 30 * time.Minute, want: "old"},
+		{name: "expired refetch", cached: true, age: 2 * time.Hour, want: "new", fetched: true},
+		{name: "stale on fetch error", cached: true, age: 48 * time.Hour, fetchErr: errDown, want: "old", fetched: true},
+		{name: "fetch error without cache", fetchErr: errDown, wantErr: true, fetched: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, now := testStore(t)
+			if tt.cached {
+				if err := s.Put("k", "test", "old"); err != nil {
+					t.Fatal(err)
+				}
+				*now = now.Add(tt.age)
+			}
+
+			fetched := false
+			got, err := Load(s, "k", "test", time.Hour, func() (string, error) {
+				fetched = true
+				return "new", tt.fetchErr
+			})
+			if (err != nil) != tt.wantErr || got != tt.want && !tt.wantErr || fetched != tt.fetched {
+				t.Errorf("got %q, %v (fetched %v); want %q (fetched %v)", got, err, fetched, tt.want, tt.fetched)
+			}
+
+			// A successful fetch is stored; a failed one keeps the old entry.
+			e, ok, _ := s.Get("k")
+			if stored := ok && e.StoredAt.Equal(*now); stored != (tt.fetched && tt.fetchErr == nil) {
+				t.Errorf("entry stored at %v (ok %v), now %v", e.StoredAt, ok, *now)
+			}
+		})
+	}
+}
+
+func TestLoadWithoutStore(t *testing.T) {
+	calls := 0
+	for range 2 {
+		Load(nil, "k", "test", time.Hour, func() (int, error) { calls++; return calls, nil })
+	}
+	if calls != 2 {
+		t.Errorf("fetch called %d times, want 2 without a store", calls)
+	}
+}
+
+type countingSource struct {
+	calls int
+	err   error
+}
+
+func (s *countingSource) ID() string { return "test" }
+
+func (s *countingSource) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
+	s.calls++
+	return []model.Event{{Name: "Konzert", StartTime: from}}, s.err
+}
+
+func TestWrap(t *testing.T) {
+	s, now := testStore(t)
+	src := &countingSource{}
+	cached := Wrap(src, s, time.Hour)
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	for range 2 {
+		if events, err := cached.Fetch(context.Background(), day, day.AddDate(0, 0, 1)); err != nil || len(events) != 1 {
+			t.Fatalf("Fetch = %v, %v", events, err)
+		}
+	}
+	if src.calls != 1 {
+		t.Errorf("source called %d times for one range, want 1", src.calls)
+	}
+	cached.Fetch(context.Background(), day, day.AddDate(0, 0, 2))
+	if src.calls != 2 {
+		t.Errorf("source called %d times for two ranges, want 2", src.calls)
+	}
+
+	*now = now.Add(2 * time.Hour)
+	src.err = errors.New("site down")
+	if events, err := cached.Fetch(context.Background(), day, day.AddDate(0, 0, 1)); err != nil || len(events) != 1 || src.calls != 3 {
+		t.Errorf("expired entry with failing source: %v, %v after %d calls", events, err, src.calls)
+	}
+
+	if n, err := s.Clear("other"); n != 0 || err != nil {
+		t.Errorf("Clear(other) = %d, %v", n, err)
+	}
+	if n, err := s.Clear("test"); n != 2 || err != nil {
+		t.Errorf("Clear(test) = %d, %v", n, err)
+	}
+}
+
+func TestEventsKey(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	a := EventsKey("prinz.de", day, day.AddDate(0, 0, 1))
+	if a != EventsKey("prinz.de", day, day.AddDate(0, 0, 1)) {
+		t.Error("key not stable")
+	}
+	for _, b := range []string{
+		EventsKey("leipzig.de", day, day.AddDate(0, 0, 1)),
+		EventsKey("prinz.de", day, day.AddDate(0, 0, 2)),
+	} {
+		if a == b {
+			t.Errorf("different source or range share key %q", a)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Load returns the cached value for key if it is younger than ttl. Otherwise
+// it calls fetch and stores the result. If fetch fails and a stale value is
+// cached, the stale value is returned with a warning on stderr.
+func Load[T any](s *Store, key, source string, ttl time.Duration, fetch func() (T, error)) (T, error) {
+	var zero T
+	if s == nil {
+		return fetch()
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	entry, ok, err := s.Get(key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		ok = false
+	}
+
+	var cached T
+	if ok {
+		if err := json.Unmarshal(entry.Data, &cached); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: cache: decode %s: %v\n", key, err)
+			ok = false
+		}
+	}
+	if ok && entry.Age(s.now()) < ttl {
+		return cached, nil
+	}
+
+	v, err := fetch()
+	if err != nil {
+		if ok {
+			fmt.Fprintf(os.Stderr, "warning: %s unreachable (%v), serving cached data from %s\n",
+				source, err, entry.StoredAt.Local().Format("02 Jan 15:04"))
+			return cached, nil
+		}
+		return zero, err
+	}
+
+	if err := s.Put(key, source, v); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+// Source wraps a source.Source so its results are cached per date range.
+type Source struct {
+	src   source.Source
+	store *Store
+	ttl   time.Duration
+}
+
+// Wrap returns src with caching. A nil store disables caching.
+func Wrap(src source.Source, store *Store, ttl time.Duration) *Source {
+	return &Source{src: src, store: store, ttl: ttl}
+}
+
+func (s *Source) ID() string { return s.src.ID() }
+
+// Unwrap returns the underlying source.
+func (s *Source) Unwrap() source.Source { return s.src }
+
+func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
+	return Load(s.store, EventsKey(s.src.ID(), from, to), s.src.ID(), s.ttl, func() ([]model.Event, error) {
+		return s.src.Fetch(ctx, from, to)
+	})
+}
+
+// EventsKey is the cache key for a source's events in [from, to].
+func EventsKey(sourceID string, from, to time.Time) string {
+	return "events|" + sourceID + "|" + from.Format(time.RFC3339) + "|" + to.Format(time.RFC3339)
+}
+
+// DetailsTTL is how long detail pages are cached; they rarely change.
+const DetailsTTL = 24 * time.Hour
+
+// FetchDetails forwards to the wrapped source's detail fetcher, caching the
+// result per event URL.
+func (s *Source) FetchDetails(ctx context.Context, e model.Event) (source.Details, error) {
+	df, ok := s.src.(source.DetailFetcher)
+	if !ok || e.URL == "" {
+		return source.Details{}, source.ErrNoDetails
+	}
+	return Load(s.store, "details|"+e.URL, s.src.ID(), DetailsTTL, func() (source.Details, error) {
+		return df.FetchDetails(ctx, e)
+	})
+}
@@ -0,0 +1,265 @@
+// Package config loads the user configuration from
+// ~/.config/leipzig/config.yaml. Every setting has a built-in default, so a
+// missing file is not an error. Selected settings can be overridden with
+// LEIPZIG_* environment variables.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/source"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the on-disk configuration.
+type Config struct {
+	// Sources holds per-source settings keyed by source ID ("leipzig.de").
+	Sources map[string]SourceConfig `yaml:"sources,omitempty"`
+
+	// Defaults holds default flag values per command, e.g.
+	// defaults.events.when = "weekend".
+	Defaults map[string]map[string]string `yaml:"defaults,omitempty"`
+
+	Cache CacheConfig `yaml:"cache,omitempty"`
+	Fetch FetchConfig `yaml:"fetch,omitempty"`
+	Merge MergeConfig `yaml:"merge,omitempty"`
+
+	path string
+	// The overrides are set from LEIPZIG_SOURCES, LEIPZIG_CACHE_DIR and
+	// LEIPZIG_CACHE_TTL and are never saved.
+	enabledOverride  map[string]bool
+	cacheDirOverride string
+	cacheTTLOverride time.Duration
+}
+
+// SourceConfig configures a single event source.
+type SourceConfig struct {
+	// Enabled defaults to true when unset.
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// Timeout overrides fetch.timeout for this source.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// URLs lists feeds or pages for generic adapters such as "ical".
+	URLs []string `yaml:"urls,omitempty"`
+	// Options are adapter-specific settings.
+	Options map[string]string `yaml:"options,omitempty"`
+}
+
+// CacheConfig configures the on-disk cache.
+type CacheConfig struct {
+	Dir string `yaml:"dir,omitempty"`
+	// TTL is the default time-to-live for cached entries.
+	TTL time.Duration `yaml:"ttl,omitempty"`
+	// TTLs overrides TTL per cache source ("prinz.de", "news", "playgrounds").
+	TTLs map[string]time.Duration `yaml:"ttls,omitempty"`
+}
+
+// Adapter returns the settings handed to the source's factory.
+func (sc SourceConfig) Adapter() source.Config {
+	return source.Config{URLs: sc.URLs, Options: sc.Options}
+}
+
+// FetchConfig configures how the engine calls sources and how politely
+// the HTTP client treats each host.
+type FetchConfig struct {
+	Concurrency int           `yaml:"concurrency,omitempty"`
+	Timeout     time.Duration `yaml:"timeout,omitempty"`
+	// Interval is the minimum spacing between requests to one host.
+	Interval   time.Duration `yaml:"interval,omitempty"`
+	MaxRetries int           `yaml:"maxRetries,omitempty"`
+}
+
+// MergeConfig sets which source wins each field when duplicate events
+// from different sources are merged. Unset, leipzig.de wins everywhere and
+// otherwise the richer value is kept.
+type MergeConfig struct {
+	// Priority orders source IDs for every field, most trusted first.
+	Priority []string `yaml:"priority,omitempty"`
+	// Fields overrides Priority per field ("price", "description").
+	Fields map[string][]string `yaml:"fields,omitempty"`
+}
+
+// Path returns the config file location. LEIPZIG_CONFIG wins, then
+// $XDG_CONFIG_HOME/leipzig/config.yaml, then ~/.config/leipzig/config.yaml.
+func Path() (string, error) {
+	if p := os.Getenv("LEIPZIG_CONFIG"); p != "" {
+		return p, nil
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "leipzig", "config.yaml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("config: locate home dir: %w", err)
+	}
+	return filepath.Join(home, ".config", "leipzig", "config.yaml"), nil
+}
+
+// Dir returns the directory holding the config file. Selector source
+// definitions live in its sources.d subdirectory.
+func Dir() (string, error) {
+	p, err := Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(p), nil
+}
+
+// Load reads the config file (if any) and applies environment overrides.
+func Load() (*Config, error) {
+	p, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(p)
+}
+
+// LoadFile reads the config at path. A missing file yields an empty config.
+func LoadFile(path string) (*Config, error) {
+	cfg := &Config{path: path}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	default:
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("config: parse %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// File returns the path the config was loaded from.
+func (c *Config) File() string { return c.path }
+
+// Save writes the config back to the file it was loaded from.
+func (c *Config) Save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("config: create dir: %w", err)
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("config: encode: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o644); err != nil {
+		return fmt.Errorf("config: write %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// Source returns the settings for a source (zero value if unconfigured).
+func (c *Config) Source(id string) SourceConfig {
+	return c.Sources[id]
+}
+
+// UnknownSources returns the configured source IDs that are neither
+// registered adapters nor in extra (e.g. selector definitions), sorted.
+func (c *Config) UnknownSources(extra ...string) []string {
+	var unknown []string
+	for id := range c.Sources {
+		if _, ok := source.Lookup(id); ok || slices.Contains(extra, id) {
+			continue
+		}
+		unknown = append(unknown, id)
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// SourceEnabled reports whether a source should be used.
+func (c *Config) SourceEnabled(id string) bool {
+	if c.enabledOverride != nil {
+		return c.enabledOverride[id]
+	}
+	sc, ok := c.Sources[id]
+	if !ok || sc.Enabled == nil {
+		return true
+	}
+	return *sc.Enabled
+}
+
+// SetSourceEnabled records whether a source is enabled. Call Save to persist.
+func (c *Config) SetSourceEnabled(id string, enabled bool) {
+	if c.Sources == nil {
+		c.Sources = make(map[string]SourceConfig)
+	}
+	sc := c.Sources[id]
+	sc.Enabled = &enabled
+	c.Sources[id] = sc
+}
+
+// CacheDir returns the configured cache directory, or "" for the default.
+func (c *Config) CacheDir() string {
+	if c.cacheDirOverride != "" {
+		return c.cacheDirOverride
+	}
+	return c.Cache.Dir
+}
+
+// TTL returns the cache TTL for a cache source, falling back to fallback
+// when neither a specific nor a default TTL is configured.
+func (c *Config) TTL(source string, fallback time.Duration) time.Duration {
+	if ttl, ok := c.Cache.TTLs[source]; ok && ttl > 0 {
+		return ttl
+	}
+	if c.cacheTTLOverride > 0 {
+		return c.cacheTTLOverride
+	}
+	if c.Cache.TTL > 0 {
+		return c.Cache.TTL
+	}
+	return fallback
+}
+
+// Default returns the configured default for a command flag. Environment
+// variables of the form LEIPZIG_<COMMAND>_<FLAG> take precedence.
+func (c *Config) Default(command, flag string) (string, bool) {
+	env := "LEIPZIG_" + envName(command) + "_" + envName(flag)
+	if v, ok := os.LookupEnv(env); ok {
+		return v, true
+	}
+	v, ok := c.Defaults[command][flag]
+	return v, ok
+}
+
+func (c *Config) applyEnv() error {
+	if v := os.Getenv("LEIPZIG_CACHE_DIR"); v != "" {
+		c.cacheDirOverride = v
+	}
+	if v := os.Getenv("LEIPZIG_CACHE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("config: LEIPZIG_CACHE_TTL: %w", err)
+		}
+		c.cacheTTLOverride = d
+	}
+	if v := os.Getenv("LEIPZIG_SOURCES"); v != "" {
+		c.enabledOverride = make(map[string]bool)
+		for _, id := range strings.Split(v, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				c.enabledOverride[id] = true
+			}
+		}
+	}
+	return nil
+}
+
+func envName(s string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(s))
+}
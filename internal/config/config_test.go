@@ -0,0 +1,138 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// clearEnv unsets the LEIPZIG_* overrides for the test.
+func clearEnv(t *testing.T) {
+	for _, k := range []string{"LEIPZIG_CACHE_DIR", "LEIPZIG_CACHE_TTL", "LEIPZIG_SOURCES", "LEIPZIG_EVENTS_WHEN"} {
+		t.Setenv(k, "")
+		os.Unsetenv(k)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, `
+sources:
+  prinz.de: {enabled: false, timeout: 5s}
+defaults:
+  events: {when: weekend}
+cache:
+  dir: /var/cache/leipzig
+  ttl: 30m
+  ttls: {news: 2h}
+`)
+	c, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.SourceEnabled("prinz.de") || !c.SourceEnabled("leipzig.de") {
+		t.Errorf("SourceEnabled: prinz.de %v, leipzig.de %v", c.SourceEnabled("prinz.de"), c.SourceEnabled("leipzig.de"))
+	}
+	if got := c.Source("prinz.de").Timeout; got != 5*time.Second {
+		t.Errorf("timeout = %v", got)
+	}
+	if v, ok := c.Default("events", "when"); !ok || v != "weekend" {
+		t.Errorf("Default(events, when) = %q, %v", v, ok)
+	}
+	if got := c.CacheDir(); got != "/var/cache/leipzig" {
+		t.Errorf("CacheDir() = %q", got)
+	}
+	if got := c.TTL("news", time.Hour); got != 2*time.Hour {
+		t.Errorf("TTL(news) = %v", got)
+	}
+	if got := c.TTL("prinz.de", time.Hour); got != 30*time.Minute {
+		t.Errorf("TTL(prinz.de) = %v", got)
+	}
+
+	if c, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml")); err != nil || c.TTL("news", time.Hour) != time.Hour {
+		t.Errorf("missing file: %v", err)
+	}
+	if _, err := LoadFile(writeConfig(t, "cache: {bogus: 1}\n")); err == nil {
+		t.Error("unknown field accepted")
+	}
+}
+
+func TestEnvOverrides(t *testing.T) {
+	path := writeConfig(t, `
+defaults:
+  events: {when: weekend}
+cache: {dir: /from/file, ttl: 30m}
+`)
+	t.Setenv("LEIPZIG_CACHE_DIR", "/from/env")
+	t.Setenv("LEIPZIG_CACHE_TTL", "5m")
+	t.Setenv("LEIPZIG_SOURCES", "prinz.de")
+	t.Setenv("LEIPZIG_EVENTS_WHEN", "tomorrow")
+
+	c, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.CacheDir(); got != "/from/env" {
+		t.Errorf("CacheDir() = %q", got)
+	}
+	if got := c.TTL("news", time.Hour); got != 5*time.Minute {
+		t.Errorf("TTL = %v", got)
+	}
+	if !c.SourceEnabled("prinz.de") || c.SourceEnabled("leipzig.de") {
+		t.Error("LEIPZIG_SOURCES not applied")
+	}
+	if v, _ := c.Default("events", "when"); v != "tomorrow" {
+		t.Errorf("Default(events, when) = %q", v)
+	}
+
+	t.Setenv("LEIPZIG_CACHE_TTL", "soon")
+	if _, err := LoadFile(path); err == nil {
+		t.Error("invalid LEIPZIG_CACHE_TTL accepted")
+	}
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	path := writeConfig(t, "cache: {dir: /from/file}\n")
+	t.Setenv("LEIPZIG_CACHE_DIR", "/from/env")
+	t.Setenv("LEIPZIG_CACHE_TTL", "5m")
+	t.Setenv("LEIPZIG_SOURCES", "prinz.de")
+
+	c, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetSourceEnabled("leipzig.de", false)
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, env := range []string{"/from/env", "5m", "prinz.de"} {
+		if strings.Contains(string(data), env) {
+			t.Errorf("saved config contains environment override %q:\n%s", env, data)
+		}
+	}
+
+	clearEnv(t)
+	c, err = LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.CacheDir() != "/from/file" || c.SourceEnabled("leipzig.de") || !c.SourceEnabled("prinz.de") {
+		t.Errorf("reloaded: dir %q, leipzig.de %v, prinz.de %v", c.CacheDir(), c.SourceEnabled("leipzig.de"), c.SourceEnabled("prinz.de"))
+	}
+}
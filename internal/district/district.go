@@ -0,0 +1,165 @@
+// Package district is the taxonomy of Leipzig's 10 Stadtbezirke (boroughs)
+// and their 63 Ortsteile (districts). Sources spell districts in many ways;
+// Lookup maps them to one canonical entry.
+package district
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed districts.yaml
+var builtin []byte
+
+// District is a Stadtbezirk or an Ortsteil.
+type District struct {
+	Name    string   `yaml:"name" json:"name"`
+	Code    string   `yaml:"code" json:"code"`
+	Aliases []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	// Parent is the Stadtbezirk of an Ortsteil; empty for a Stadtbezirk.
+	Parent string `yaml:"-" json:"parent,omitempty"`
+}
+
+// IsOrtsteil reports whether d is an Ortsteil rather than a Stadtbezirk.
+func (d District) IsOrtsteil() bool { return d.Parent != "" }
+
+type file struct {
+	Stadtbezirke []struct {
+		District  `yaml:",inline"`
+		Ortsteile []District `yaml:"ortsteile"`
+	} `yaml:"stadtbezirke"`
+}
+
+var (
+	all   []District     // each Stadtbezirk followed by its Ortsteile
+	byKey map[string]int // key(name or alias) -> index in all
+)
+
+func init() {
+	var f file
+	if err := yaml.Unmarshal(builtin, &f); err != nil {
+		panic(fmt.Errorf("district: parse built-in districts: %w", err))
+	}
+	byKey = make(map[string]int)
+	for _, sb := range f.Stadtbezirke {
+		all = append(all, sb.District)
+		for _, o := range sb.Ortsteile {
+			o.Parent = sb.Name
+			all = append(all, o)
+		}
+	}
+	for i, d := range all {
+		for _, name := range append([]string{d.Name}, d.Aliases...) {
+			for _, k := range keys(name) {
+				byKey[k] = i
+			}
+		}
+	}
+}
+
+// All returns every Stadtbezirk, each followed by its Ortsteile, in the
+// order of the city's codes.
+func All() []District {
+	return append([]District(nil), all...)
+}
+
+// Stadtbezirke returns the 10 Stadtbezirke.
+func Stadtbezirke() []District {
+	var result []District
+	for _, d := range all {
+		if !d.IsOrtsteil() {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// Ortsteile returns the Ortsteile of the given Stadtbezirk, or all 63 if
+// stadtbezirk is empty.
+func Ortsteile(stadtbezirk string) []District {
+	var result []District
+	for _, d := range all {
+		if d.IsOrtsteil() && (stadtbezirk == "" || d.Parent == stadtbezirk) {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// Lookup finds a district by name, alias or code. Case, hyphens, spaces
+// and the spelling of umlauts ("Südvorstadt", "Suedvorstadt",
+// "sudvorstadt") don't matter.
+func Lookup(name string) (District, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return District{}, false
+	}
+	for _, d := range all {
+		if d.Code == name {
+			return d, true
+		}
+	}
+	for _, k := range keys(name) {
+		if i, ok := byKey[k]; ok {
+			return all[i], true
+		}
+	}
+	return District{}, false
+}
+
+// Within reports whether the place in district name is in district d:
+// name is d itself or one of its Ortsteile. Unknown names are in no
+// district.
+func Within(name, d string) bool {
+	a, ok1 := Lookup(name)
+	b, ok2 := Lookup(d)
+	return ok1 && ok2 && (a.Name == b.Name || a.Parent == b.Name)
+}
+
+// Complete returns the districts whose name starts with prefix, for shell
+// completion. A name typed without umlauts completes to its ASCII
+// spelling, which Lookup accepts too.
+func Complete(prefix string) []string {
+	p := strings.ToLower(prefix)
+	var result []string
+	for _, d := range all {
+		switch ascii := fold(d.Name, "ae"); {
+		case strings.HasPrefix(strings.ToLower(d.Name), p):
+			result = append(result, d.Name)
+		case strings.HasPrefix(strings.ToLower(ascii), p):
+			result = append(result, ascii)
+		}
+	}
+	return result
+}
+
+// keys returns the lookup keys of a name: lowercase letters and digits
+// only, with umlauts spelled both "ae" and "a".
+func keys(name string) []string {
+	name = strings.ToLower(name)
+	var result []string
+	for _, umlaut := range []string{"ae", "a"} {
+		k := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, fold(name, umlaut))
+		if k != "" && (len(result) == 0 || result[0] != k) {
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+// fold spells umlauts in ASCII, as "ae" or as "a", and ß as "ss".
+func fold(s, umlaut string) string {
+	if umlaut == "ae" {
+		return strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "Ä", "Ae", "Ö", "Oe", "Ü", "Ue", "ß", "ss").Replace(s)
+	}
+	return strings.NewReplacer("ä", "a", "ö", "o", "ü", "u", "Ä", "A", "Ö", "O", "Ü", "U", "ß", "ss").Replace(s)
+}
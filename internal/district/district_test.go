@@ -0,0 +1,77 @@
+package district
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBuiltinDistricts(t *testing.T) {
+	if n := len(Stadtbezirke()); n != 10 {
+		t.Errorf("%d Stadtbezirke, want 10", n)
+	}
+	if n := len(Ortsteile("")); n != 63 {
+		t.Errorf("%d Ortsteile, want 63", n)
+	}
+	// Every name and alias must find its own district.
+	for _, d := range All() {
+		for _, name := range append([]string{d.Name, d.Code}, d.Aliases...) {
+			if got, ok := Lookup(name); !ok || got.Name != d.Name {
+				t.Errorf("Lookup(%q) = %q, %v; want %q", name, got.Name, ok, d.Name)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := map[string]string{
+		"Südvorstadt":        "Südvorstadt",
+		"suedvorstadt":       "Südvorstadt",
+		"SUDVORSTADT":        "Südvorstadt",
+		"zentrum sud":        "Zentrum-Süd",
+		"Loessnig":           "Lößnig",
+		"Plaussig-Portitz":   "Plaußig-Portitz",
+		"Reudnitz":           "Reudnitz-Thonberg",
+		"alt west":           "Alt-West",
+		"41":                 "Connewitz",
+		"Grünau":             "",
+		"Leipzig-Connewitz ": "",
+	}
+	for name, want := range tests {
+		d, ok := Lookup(name)
+		if d.Name != want || ok != (want != "") {
+			t.Errorf("Lookup(%q) = %q, %v; want %q", name, d.Name, ok, want)
+		}
+	}
+	if d, _ := Lookup("Connewitz"); d.Parent != "Süd" {
+		t.Errorf("Connewitz parent = %q", d.Parent)
+	}
+}
+
+func TestWithin(t *testing.T) {
+	tests := []struct {
+		name, district string
+		want           bool
+	}{
+		{"Connewitz", "Süd", true},
+		{"Connewitz", "connewitz", true},
+		{"Süd", "Süd", true},
+		{"Connewitz", "Südwest", false},
+		{"Süd", "Connewitz", false},
+		{"", "Süd", false},
+		{"Atlantis", "Süd", false},
+	}
+	for _, tt := range tests {
+		if got := Within(tt.name, tt.district); got != tt.want {
+			t.Errorf("Within(%q, %q) = %v", tt.name, tt.district, got)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	if got := Complete("Gohlis"); !slices.Equal(got, []string{"Gohlis-Süd", "Gohlis-Mitte", "Gohlis-Nord"}) {
+		t.Errorf("Complete(Gohlis) = %q", got)
+	}
+	if got := Complete("sued"); !slices.Equal(got, []string{"Suedost", "Sued", "Suedvorstadt", "Suedwest"}) {
+		t.Errorf("Complete(sued) = %q", got)
+	}
+}
@@ -1,53 +1,104 @@
 package engine
 
 import (
+	"slices"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/venue"
 )
 
-// Dedup removes cross-source duplicate events. It groups by normalized venue +
-// date + hour, then checks name similarity within each group.
-func Dedup(events []model.Event) []model.Event {
-	type key struct {
-		venue string
-		date  string // "2006-01-02"
-		hour  int    // -1 means midnight/zero-time
+const (
+	// timeTolerance is how far apart two listings of one event may start.
+	timeTolerance = time.Hour
+	// minNameScore and minScore are the thresholds for a merge.
+	minNameScore = 0.6
+	minScore     = 0.7
+	// minVenueScore makes two events candidates at all.
+	minVenueScore = 0.5
+)
+
+// Match explains one merge made by Dedup. Kept and Merged are the two
+// events as they were before merging; the merged event keeps Kept's source
+// and URL.
+type Match struct {
+	Kept, Merged model.Event
+	// Score is the weighted total of the name, venue and time scores,
+	// each between 0 and 1.
+	Score, Name, Venue, Time float64
+}
+
+// Dedup merges cross-source duplicate events. Events on the same day, or
+// on either side of midnight, are candidates when they start within an
+// hour of each other (or, on the same day, either has no time of day) at
+// similar venues; candidates with similar names are
+// merged field by field (see Priority). The merged event's Sources lists
+// every source the duplicates came from. The returned matches explain each
+// merge, in date order.
+func Dedup(events []model.Event, p Priority) ([]model.Event, []Match) {
+	events = append([]model.Event(nil), events...)
+	keys := make([]dedupKey, len(events))
+	for i := range events {
+		events[i].AddSource(model.SourceRef{Source: events[i].Source, URL: events[i].URL})
+		keys[i] = newDedupKey(events[i])
 	}
 
-	groups := make(map[key][]int) // key -> indices
+	blocks := make(map[string][]int) // date -> indices
 	for i, e := range events {
-		k := key{
-			venue: normalizeVenue(e.Venue),
-			date:  e.StartTime.Format("2006-01-02"),
-			hour:  e.StartTime.Hour(),
-		}
-		groups[k] = append(groups[k], i)
+		date := e.StartTime.Format("2006-01-02")
+		blocks[date] = append(blocks[date], i)
+	}
+	dates := make([]string, 0, len(blocks))
+	for d := range blocks {
+		dates = append(dates, d)
 	}
+	sort.Strings(dates)
 
 	removed := make(map[int]bool)
+	var matches []Match
 
-	for _, indices := range groups {
-		if len(indices) < 2 {
-			continue
+	for _, date := range dates {
+		indices := blocks[date]
+		// Events just after the next midnight may be late ones listed a
+		// few minutes off.
+		var early []int
+		day, _ := time.ParseInLocation("2006-01-02", date, events[indices[0]].StartTime.Location())
+		next := day.AddDate(0, 0, 1)
+		for _, k := range blocks[next.Format("2006-01-02")] {
+			if !keys[k].allDay && keys[k].start.Sub(next) <= timeTolerance {
+				early = append(early, k)
+			}
 		}
 		for i := 0; i < len(indices); i++ {
 			if removed[indices[i]] {
 				continue
 			}
-			for j := i + 1; j < len(indices); j++ {
-				if removed[indices[j]] {
+			candidates := indices[i+1:]
+			if !keys[indices[i]].allDay && len(early) > 0 {
+				candidates = append(slices.Clip(candidates), early...)
+			}
+			for _, j := range candidates {
+				if removed[j] {
 					continue
 				}
-				a, b := events[indices[i]], events[indices[j]]
+				a, b := events[indices[i]], events[j]
 				// Only dedup cross-source
-				if a.Source == b.Source {
+				if sharesSource(a, b) {
 					continue
 				}
-				if namesMatch(a.Name, b.Name) {
-					loser := pickLoser(a, b, indices[i], indices[j])
-					removed[loser] = true
+				m, ok := score(keys[indices[i]], keys[j])
+				if !ok {
+					continue
 				}
+				m.Kept, m.Merged = p.base(a, b)
+				matches = append(matches, m)
+
+				events[indices[i]] = p.merge(a, b)
+				keys[indices[i]] = newDedupKey(events[indices[i]])
+				removed[j] = true
 			}
 		}
 	}
@@ -58,47 +109,106 @@ func Dedup(events []model.Event) []model.Event {
 			result = append(result, e)
 		}
 	}
-	return result
+	return result, matches
 }
 
-func normalizeVenue(v string) string {
-	v = strings.ToLower(strings.TrimSpace(v))
-	v = strings.TrimSuffix(v, "leipzig")
-	v = strings.TrimSuffix(v, ",")
-	v = strings.TrimSpace(v)
-	return v
+// sharesSource reports whether a and b (possibly merged already) have a
+// source in common. Two listings from one source are two events.
+func sharesSource(a, b model.Event) bool {
+	for _, ra := range a.Sources {
+		for _, rb := range b.Sources {
+			if ra.Source == rb.Source {
+				return true
+			}
+		}
+	}
+	return false
 }
 
-func normalizeName(n string) string {
-	n = strings.ToLower(strings.TrimSpace(n))
-	n = strings.ReplaceAll(n, "\u2013", "-") // en-dash
-	n = strings.ReplaceAll(n, "\u2014", "-") // em-dash
-	// collapse whitespace
-	fields := strings.Fields(n)
-	return strings.Join(fields, " ")
+// dedupKey holds the normalized parts of an event that Dedup compares.
+type dedupKey struct {
+	start  time.Time
+	allDay bool
+
+	name      string
+	nameWords map[string]bool
+	nameGrams map[string]bool
+
+	venue      string // registry ID, else venue.Key; "" if unknown
+	venueGrams map[string]bool
+}
+
+func newDedupKey(e model.Event) dedupKey {
+	name := normalizeName(e.Name)
+	v := e.VenueID
+	if v == "" {
+		v = venue.Key(e.Venue)
+	}
+	return dedupKey{
+		start:      e.StartTime,
+		allDay:     e.StartTime.Hour() == 0 && e.StartTime.Minute() == 0,
+		name:       name,
+		nameWords:  words(name),
+		nameGrams:  trigrams(name),
+		venue:      v,
+		venueGrams: trigrams(v),
+	}
+}
+
+// score compares two events on the same day, or a late and an early one
+// across midnight. ok is false unless they are
+// similar enough to merge.
+func score(a, b dedupKey) (m Match, ok bool) {
+	switch {
+	case a.allDay || b.allDay:
+		m.Time = 0.7
+	default:
+		diff := a.start.Sub(b.start).Abs()
+		if diff > timeTolerance {
+			return m, false
+		}
+		m.Time = 1 - float64(diff)/float64(2*timeTolerance)
+	}
+
+	switch {
+	case a.venue == "" || b.venue == "":
+		m.Venue = 0.5
+	case a.venue == b.venue:
+		m.Venue = 1
+	default:
+		m.Venue = max(dice(a.venueGrams, b.venueGrams), containment(words(a.venue), words(b.venue)))
+	}
+	if m.Venue < minVenueScore {
+		return m, false
+	}
+
+	m.Name = nameScore(a, b)
+	m.Score = 0.6*m.Name + 0.25*m.Venue + 0.15*m.Time
+	return m, m.Name >= minNameScore && m.Score >= minScore
 }
 
-func namesMatch(a, b string) bool {
-	na, nb := normalizeName(a), normalizeName(b)
-	if na == nb {
-		return true
+// nameScore rates how likely two names denote the same event: the better
+// of trigram and token-set similarity, raised for one name containing the
+// other, a long common prefix or shared distinctive words.
+func nameScore(a, b dedupKey) float64 {
+	if a.name == b.name {
+		return 1
 	}
-	if strings.Contains(na, nb) || strings.Contains(nb, na) {
-		return true
+	s := max(dice(a.nameGrams, b.nameGrams), containment(a.nameWords, b.nameWords))
+	if strings.Contains(a.name, b.name) || strings.Contains(b.name, a.name) {
+		s = max(s, 0.9)
 	}
 	// Long common prefix (20+ chars)
 	const prefixLen = 20
-	if len(na) >= prefixLen && len(nb) >= prefixLen && na[:prefixLen] == nb[:prefixLen] {
-		return true
+	if len(a.name) >= prefixLen && len(b.name) >= prefixLen && a.name[:prefixLen] == b.name[:prefixLen] {
+		s = max(s, 0.85)
 	}
 	// Shared distinctive words — catches renamed same-event cases
 	// Require 2+ shared significant words, or 1 shared word that's 10+ chars
-	wordsA := significantWords(na)
-	wordsB := significantWords(nb)
 	shared := 0
 	longShared := false
-	for w := range wordsA {
-		if wordsB[w] {
+	for w := range significantWords(a.name) {
+		if b.nameWords[w] {
 			shared++
 			if len(w) >= 10 {
 				longShared = true
@@ -106,29 +216,85 @@ func namesMatch(a, b string) bool {
 		}
 	}
 	if shared >= 2 || longShared {
-		return true
+		s = max(s, 0.8)
 	}
-	return false
+	return s
 }
 
-// pickLoser returns the index of the event to remove.
-// Prefer keeping leipzig.de; if tied on source, keep the richer one.
-func pickLoser(a, b model.Event, idxA, idxB int) int {
-	scoreA := richness(a)
-	scoreB := richness(b)
+// normalizeName lowercases, folds umlauts and reduces punctuation to
+// single spaces.
+func normalizeName(n string) string {
+	n = strings.NewReplacer("ä", "a", "ö", "o", "ü", "u", "ß", "ss").Replace(strings.ToLower(n))
+	fields := strings.FieldsFunc(n, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
 
-	// Prefer leipzig.de as canonical source
-	if a.Source == "leipzig.de" && b.Source != "leipzig.de" {
-		scoreA += 100
+// words returns the set of words in a normalized string, minus filler.
+func words(s string) map[string]bool {
+	result := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		if !fillerWords[w] {
+			result[w] = true
+		}
 	}
-	if b.Source == "leipzig.de" && a.Source != "leipzig.de" {
-		scoreB += 100
+	return result
+}
+
+var fillerWords = map[string]bool{
+	"der": true, "die": true, "das": true, "und": true, "mit": true,
+	"im": true, "in": true, "am": true, "zu": true, "the": true, "and": true,
+	"live": true, "leipzig": true,
+}
+
+// trigrams returns the character trigrams of s, padded so short words count.
+func trigrams(s string) map[string]bool {
+	result := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		r := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			result[string(r[i:i+3])] = true
+		}
 	}
+	return result
+}
 
-	if scoreA >= scoreB {
-		return idxB // remove B
+// dice is the Sørensen–Dice coefficient of two sets.
+func dice(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for g := range a {
+		if b[g] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
+
+// containment is the token-set similarity of two word sets: the share of
+// the smaller set found in the larger one. A single shared word only
+// counts half, so "Konzert" doesn't match every concert.
+func containment(a, b map[string]bool) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(a) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	s := float64(shared) / float64(len(a))
+	if len(a) == 1 {
+		s /= 2
 	}
-	return idxA // remove A
+	return s
 }
 
 // significantWords returns distinctive words (6+ chars, not common German stopwords).
@@ -136,7 +302,7 @@ func significantWords(s string) map[string]bool {
 	stop := map[string]bool{
 		"leipzig": true, "leipziger": true, "veranstaltung": true,
 		"durch": true, "einen": true, "einer": true, "diesem": true,
-		"dieser": true, "werden": true, "können": true,
+		"dieser": true, "werden": true, "konnen": true,
 	}
 	result := make(map[string]bool)
 	for _, w := range strings.Fields(s) {
@@ -0,0 +1,232 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+func TestDedupRecordsAllSources(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	start := time.Date(2026, 10, 16, 20, 0, 0, 0, loc)
+	events := []model.Event{
+		{Name: "Moop Mama", StartTime: start, Venue: "Conne Island", Source: "prinz.de", URL: "https://prinz.de/moop"},
+		{Name: "Moop Mama", StartTime: start, Venue: "Conne Island", Source: "leipzig.de", URL: "https://www.leipzig.de/moop"},
+		{Name: "Other Band", StartTime: start, Venue: "Conne Island", Source: "prinz.de"},
+	}
+
+	got, _ := Dedup(events, DefaultPriority)
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2", len(got))
+	}
+	kept := got[0]
+	if kept.Source != "leipzig.de" {
+		t.Errorf("kept source %s, want leipzig.de", kept.Source)
+	}
+	want := []model.SourceRef{
+		{Source: "leipzig.de", URL: "https://www.leipzig.de/moop"},
+		{Source: "prinz.de", URL: "https://prinz.de/moop"},
+	}
+	if len(kept.Sources) != len(want) || kept.Sources[0] != want[0] || kept.Sources[1] != want[1] {
+		t.Errorf("Sources = %v, want %v", kept.Sources, want)
+	}
+	if len(events[1].Sources) != 0 {
+		t.Error("Dedup modified its input")
+	}
+}
+
+func TestDedupMergesFields(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	start := time.Date(2026, 10, 16, 20, 0, 0, 0, loc)
+	lde := model.Event{
+		Name: "Moop Mama", StartTime: start, Venue: "Conne Island", Category: model.CategoryOther,
+		Description: "Brass-Rap aus München.", Tags: []string{"live"}, Source: "leipzig.de",
+	}
+	prinz := model.Event{
+		Name: "Moop Mama", StartTime: start, Venue: "Conne Island", Category: model.CategoryConcert,
+		Description: "Die Münchner Brass-Band auf Tour.", Price: "25 €", ImageURL: "https://prinz.de/moop.jpg",
+		EndTime: start.Add(2 * time.Hour), Tags: []string{"live", "hiphop"}, Source: "prinz.de",
+	}
+
+	tests := []struct {
+		name     string
+		priority Priority
+		desc     string
+		fromDesc string
+	}{
+		{"default", DefaultPriority, lde.Description, "leipzig.de"},
+		{"field override", Priority{Sources: []string{"leipzig.de"}, Fields: map[string][]string{"description": {"prinz.de"}}}, prinz.Description, "prinz.de"},
+		{"richest", Priority{}, prinz.Description, "prinz.de"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, matches := Dedup([]model.Event{prinz, lde}, tt.priority)
+			if len(got) != 1 {
+				t.Fatalf("got %d events, want 1", len(got))
+			}
+			m := got[0]
+			if len(matches) != 1 || matches[0].Kept.Source != m.Source || matches[0].Merged.Source == m.Source {
+				t.Errorf("match labels kept %s, merged %s; event kept %s", matches[0].Kept.Source, matches[0].Merged.Source, m.Source)
+			}
+			if m.Description != tt.desc || m.FieldSources["description"] != tt.fromDesc {
+				t.Errorf("description = %q from %s, want %q from %s", m.Description, m.FieldSources["description"], tt.desc, tt.fromDesc)
+			}
+			if m.Price != "25 €" || m.ImageURL == "" || m.EndTime.IsZero() || m.Category != model.CategoryConcert {
+				t.Errorf("merged event lost fields: %+v", m)
+			}
+			if m.FieldSources["price"] != "prinz.de" || m.FieldSources["name"] == "" {
+				t.Errorf("FieldSources = %v", m.FieldSources)
+			}
+			if len(m.Tags) != 2 {
+				t.Errorf("Tags = %v, want live and hiphop", m.Tags)
+			}
+			if len(m.Sources) != 2 {
+				t.Errorf("Sources = %v", m.Sources)
+			}
+		})
+	}
+}
+
+func TestDedupTimeOverPlaceholder(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, loc)
+	lde := model.Event{Name: "Moop Mama", StartTime: day, Venue: "Conne Island", Source: "leipzig.de"}
+	prinz := model.Event{Name: "Moop Mama", StartTime: day.Add(20 * time.Hour), Venue: "Conne Island", Source: "prinz.de"}
+
+	for _, p := range []Priority{
+		DefaultPriority,
+		{Sources: []string{"prinz.de"}, Fields: map[string][]string{"startTime": {"leipzig.de"}}},
+	} {
+		got, _ := Dedup([]model.Event{lde, prinz}, p)
+		if len(got) != 1 {
+			t.Fatalf("got %d events, want 1", len(got))
+		}
+		if m := got[0]; !m.StartTime.Equal(prinz.StartTime) || m.FieldSources["startTime"] != "prinz.de" {
+			t.Errorf("%v: start = %v from %s, want 20:00 from prinz.de", p, m.StartTime, m.FieldSources["startTime"])
+		}
+	}
+}
+
+func TestDedupKeepsStartWithEnd(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, loc)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	event := func(source string, start, end time.Time) model.Event {
+		return model.Event{Name: "Moop Mama", Venue: "Conne Island", StartTime: start, EndTime: end, Source: source}
+	}
+
+	tests := []struct {
+		name       string
+		lde, prinz model.Event
+		start, end time.Time
+		endFrom    string
+	}{
+		{
+			"end of another start",
+			event("leipzig.de", at(21, 0), time.Time{}), event("prinz.de", at(20, 0), at(20, 50)),
+			at(21, 0), time.Time{}, "",
+		},
+		{
+			"date range against a time of day",
+			event("leipzig.de", day, day.AddDate(0, 0, 9)), event("prinz.de", at(20, 0), time.Time{}),
+			at(20, 0), time.Time{}, "",
+		},
+		{
+			"end for the same start",
+			event("leipzig.de", at(20, 0), time.Time{}), event("prinz.de", at(20, 0), at(22, 0)),
+			at(20, 0), at(22, 0), "prinz.de",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := Dedup([]model.Event{tt.lde, tt.prinz}, DefaultPriority)
+			if len(got) != 1 {
+				t.Fatalf("got %d events, want 1", len(got))
+			}
+			m := got[0]
+			if !m.StartTime.Equal(tt.start) || !m.EndTime.Equal(tt.end) || m.FieldSources["endTime"] != tt.endFrom {
+				t.Errorf("got %v – %v (end from %q), want %v – %v (end from %q)",
+					m.StartTime, m.EndTime, m.FieldSources["endTime"], tt.start, tt.end, tt.endFrom)
+			}
+		})
+	}
+}
+
+func TestDedupFuzzy(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	at := func(h, m int) time.Time { return time.Date(2026, 10, 16, h, m, 0, 0, loc) }
+
+	tests := []struct {
+		name  string
+		a, b  model.Event
+		merge bool
+	}{
+		{
+			"venue room and suffix",
+			model.Event{Name: "Großes Concert: Beethoven 9", StartTime: at(20, 0), Venue: "Gewandhaus zu Leipzig"},
+			model.Event{Name: "Grosses Concert – Beethoven 9", StartTime: at(20, 0), Venue: "Gewandhaus – Großer Saal"},
+			true,
+		},
+		{
+			"half an hour apart",
+			model.Event{Name: "Moop Mama", StartTime: at(19, 30), Venue: "Conne Island"},
+			model.Event{Name: "Moop Mama – Live", StartTime: at(20, 0), Venue: "Conne Island, Leipzig"},
+			true,
+		},
+		{
+			"all-day entry",
+			model.Event{Name: "Herbstflohmarkt Plagwitz", StartTime: at(0, 0), Venue: "Markthalle"},
+			model.Event{Name: "Herbstflohmarkt in Plagwitz", StartTime: at(10, 0), Venue: "Markthalle Plagwitz"},
+			true,
+		},
+		{
+			"registry venue",
+			model.Event{Name: "Tosca", StartTime: at(19, 0), Venue: "Opernhaus", VenueID: "oper-leipzig"},
+			model.Event{Name: "Tosca", StartTime: at(19, 0), Venue: "Oper Leipzig", VenueID: "oper-leipzig"},
+			true,
+		},
+		{
+			"across midnight",
+			model.Event{Name: "Nachtkonzert", StartTime: at(23, 30), Venue: "Conne Island"},
+			model.Event{Name: "Nachtkonzert", StartTime: at(24, 15), Venue: "Conne Island"},
+			true,
+		},
+		{
+			"next day's all-day entry",
+			model.Event{Name: "Nachtkonzert", StartTime: at(23, 30), Venue: "Conne Island"},
+			model.Event{Name: "Nachtkonzert", StartTime: at(24, 0), Venue: "Conne Island"},
+			false,
+		},
+		{
+			"two hours apart",
+			model.Event{Name: "Moop Mama", StartTime: at(18, 0), Venue: "Conne Island"},
+			model.Event{Name: "Moop Mama", StartTime: at(20, 0), Venue: "Conne Island"},
+			false,
+		},
+		{
+			"different venue",
+			model.Event{Name: "Tosca", StartTime: at(19, 0), Venue: "Oper Leipzig"},
+			model.Event{Name: "Tosca", StartTime: at(19, 0), Venue: "Westbad"},
+			false,
+		},
+		{
+			"different event",
+			model.Event{Name: "Konzert: Moop Mama", StartTime: at(20, 0), Venue: "Conne Island"},
+			model.Event{Name: "Konzert: Jinjer", StartTime: at(20, 0), Venue: "Conne Island"},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.a.Source, tt.b.Source = "leipzig.de", "prinz.de"
+			got, matches := Dedup([]model.Event{tt.a, tt.b}, DefaultPriority)
+			if merged := len(got) == 1; merged != tt.merge {
+				t.Errorf("merged = %v, want %v (matches %+v)", merged, tt.merge, matches)
+			}
+			if len(matches) != 2-len(got) {
+				t.Errorf("got %d matches for %d merges", len(matches), 2-len(got))
+			}
+		})
+	}
+}
@@ -2,49 +2,249 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
+	"sync"
 	"time"
 
+	"github.com/havocked/leipzig-cli/internal/district"
+	"github.com/havocked/leipzig-cli/internal/geo"
 	"github.com/havocked/leipzig-cli/internal/model"
 	"github.com/havocked/leipzig-cli/internal/source"
+	"github.com/havocked/leipzig-cli/internal/venue"
+)
+
+const (
+	// DefaultConcurrency is the number of sources fetched in parallel.
+	DefaultConcurrency = 4
+	// DefaultSourceTimeout bounds a single source's Fetch call.
+	DefaultSourceTimeout = 45 * time.Second
 )
 
 type Engine struct {
 	sources []source.Source
+
+	// Concurrency limits how many sources are fetched at once (<= 0 means DefaultConcurrency).
+	Concurrency int
+	// SourceTimeout is the deadline applied to each source (<= 0 disables it).
+	SourceTimeout time.Duration
+	// Timeouts overrides SourceTimeout for individual source IDs.
+	Timeouts map[string]time.Duration
+	// Enrich follows each event's URL to its detail page, for sources
+	// implementing source.DetailFetcher.
+	Enrich bool
+	// Priority decides which source supplies each field when duplicates
+	// are merged.
+	Priority Priority
+	// Explain, if set, is called for every merge Dedup makes.
+	Explain func(Match)
+	// Venues links events to known venues before deduplication (nil
+	// skips this).
+	Venues *venue.Registry
+	// Places locates events the registry didn't place from their address,
+	// offline (nil skips this).
+	Places *geo.Geocoder
 }
 
 func New(sources ...source.Source) *Engine {
-	return &Engine{sources: sources}
+	return &Engine{
+		sources:       sources,
+		Concurrency:   DefaultConcurrency,
+		SourceTimeout: DefaultSourceTimeout,
+		Priority:      DefaultPriority,
+		Venues:        venue.Default(),
+		Places:        geo.NewGeocoder(geo.NewGazetteer(), nil, nil),
+	}
+}
+
+// fetchResult holds the outcome of one source's Fetch call.
+type fetchResult struct {
+	events []model.Event
+	err    error
 }
 
 func (e *Engine) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
-	var all []model.Event
+	results := e.fetchAll(ctx, from, to)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
-	for _, src := range e.sources {
-		events, err := src.Fetch(ctx, from, to)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "warning: source %s failed: %v\n", src.ID(), err)
+	// Merge in source order so the output doesn't depend on which
+	// source happened to answer first.
+	var all []model.Event
+	for i, src := range e.sources {
+		r := results[i]
+		if r.err != nil {
+			fmt.Fprintf(os.Stderr, "warning: source %s failed: %v\n", src.ID(), r.err)
 			continue
 		}
-		all = append(all, events...)
+		all = append(all, r.events...)
 	}
 
-	all = Dedup(all)
+	if e.Venues != nil {
+		for i := range all {
+			e.Venues.Enrich(&all[i])
+		}
+	}
 
-	// Populate map URLs
+	all, matches := Dedup(all, e.Priority)
+	if e.Explain != nil {
+		for _, m := range matches {
+			e.Explain(m)
+		}
+	}
+
+	if e.Enrich {
+		e.enrich(ctx, all)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Populate IDs, coordinates, districts, pricing and map links
 	for i := range all {
-		all[i].MapURL = all[i].MapsURL()
+		ev := &all[i]
+		venueKey := ev.VenueID
+		if venueKey == "" {
+			venueKey = venue.Key(ev.Venue)
+		}
+		ev.ID = model.EventID(ev.Name, ev.StartTime, venueKey)
+		located := ev.Lat != 0 || ev.Lon != 0
+		if e.Places != nil && (!located || ev.District == "") && ev.Address != "" {
+			if p, ok := e.Places.Lookup(ev.Address); ok {
+				// A street's midpoint can be kilometres off; such events
+				// keep their address search links instead of a wrong pin.
+				if !located && p.Kind != "street" {
+					ev.Lat, ev.Lon = p.Lat, p.Lon
+				}
+				if ev.District == "" {
+					ev.District = p.Ortsteil
+				}
+			}
+		}
+		if d, ok := district.Lookup(ev.District); ok {
+			ev.District = d.Name
+		}
+		pricing := model.ParsePrice(ev.Price)
+		ev.Pricing = &pricing
+		links := geo.MapLinks(geo.Point{Lat: ev.Lat, Lon: ev.Lon}, ev.Location())
+		ev.MapURL, ev.OSMURL, ev.GeoURI = links.Google, links.OSM, links.GeoURI
 	}
 
-	sort.Slice(all, func(i, j int) bool {
+	sort.SliceStable(all, func(i, j int) bool {
 		return all[i].StartTime.Before(all[j].StartTime)
 	})
 
 	return all, nil
 }
 
+// fetchAll runs every source through a bounded worker pool. The returned
+// slice is indexed like e.sources.
+func (e *Engine) fetchAll(ctx context.Context, from, to time.Time) []fetchResult {
+	results := make([]fetchResult, len(e.sources))
+
+	workers := e.Concurrency
+	if workers <= 0 {
+		workers = DefaultConcurrency
+	}
+	sem := make(chan struct{}, workers)
+
+	var wg sync.WaitGroup
+	for i, src := range e.sources {
+		wg.Add(1)
+		go func(i int, src source.Source) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i].err = ctx.Err()
+				return
+			}
+
+			results[i] = e.fetchOne(ctx, src, from, to)
+		}(i, src)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (e *Engine) fetchOne(ctx context.Context, src source.Source, from, to time.Time) fetchResult {
+	// Don't ask sources for dates beyond what they list.
+	if d, ok := source.DescriptionOf(src); ok && d.Horizon > 0 && from.After(time.Now().Add(d.Horizon)) {
+		return fetchResult{}
+	}
+
+	timeout := e.SourceTimeout
+	if t, ok := e.Timeouts[src.ID()]; ok {
+		timeout = t
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	events, err := src.Fetch(ctx, from, to)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %s: %w", timeout, err)
+	}
+	return fetchResult{events: events, err: err}
+}
+
+// enrich fills in events from their detail pages. Sources are handled in
+// parallel; each source's pages are fetched one after another so the
+// adapter's own rate limit applies.
+func (e *Engine) enrich(ctx context.Context, events []model.Event) {
+	bySource := make(map[string][]int)
+	for i, ev := range events {
+		if ev.URL != "" {
+			bySource[ev.Source] = append(bySource[ev.Source], i)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, src := range e.sources {
+		df, ok := src.(source.DetailFetcher)
+		indices := bySource[src.ID()]
+		if !ok || len(indices) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(id string, df source.DetailFetcher, indices []int) {
+			defer wg.Done()
+
+			failed := 0
+			var lastErr error
+			for _, i := range indices {
+				if ctx.Err() != nil {
+					return
+				}
+				d, err := df.FetchDetails(ctx, events[i])
+				if errors.Is(err, source.ErrNoDetails) {
+					continue
+				}
+				if err != nil {
+					failed++
+					lastErr = err
+					continue
+				}
+				d.Apply(&events[i])
+			}
+			if failed > 0 {
+				fmt.Fprintf(os.Stderr, "warning: source %s: %d of %d detail pages failed (last: %v)\n",
+					id, failed, len(indices), lastErr)
+			}
+		}(src.ID(), df, indices)
+	}
+	wg.Wait()
+}
+
 func (e *Engine) Sources() []source.Source {
 	return e.sources
 }
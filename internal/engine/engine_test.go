@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+// fakeSource returns one event named after it after delay, or blocks
+// until its context is done.
+type fakeSource struct {
+	id    string
+	delay time.Duration
+	block bool
+	err   error
+	// active and peak count concurrent Fetch calls across sources.
+	active, peak *atomic.Int32
+}
+
+func (s *fakeSource) ID() string { return s.id }
+
+func (s *fakeSource) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
+	if s.active != nil {
+		n := s.active.Add(1)
+		defer s.active.Add(-1)
+		for p := s.peak.Load(); n > p && !s.peak.CompareAndSwap(p, n); p = s.peak.Load() {
+		}
+	}
+	if s.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []model.Event{{Name: "Event from " + s.id, Venue: s.id, StartTime: from, Source: s.id}}, nil
+}
+
+// newTestEngine returns an engine without venue and address lookups.
+func newTestEngine(sources ...source.Source) *Engine {
+	e := New(sources...)
+	e.Venues, e.Places = nil, nil
+	return e
+}
+
+func names(events []model.Event) []string {
+	var result []string
+	for _, e := range events {
+		result = append(result, e.Name)
+	}
+	return result
+}
+
+func TestFetchSourceTimeout(t *testing.T) {
+	from := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	e := newTestEngine(
+		&fakeSource{id: "stuck", block: true},
+		&fakeSource{id: "broken", err: errors.New("HTTP 500")},
+		&fakeSource{id: "slow", delay: 100 * time.Millisecond},
+		&fakeSource{id: "fast"},
+	)
+	e.SourceTimeout = 50 * time.Millisecond
+	e.Timeouts = map[string]time.Duration{"slow": time.Second}
+
+	began := time.Now()
+	got, err := e.Fetch(context.Background(), from, from.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Event from slow", "Event from fast"}; !slices.Equal(names(got), want) {
+		t.Errorf("got %v, want %v", names(got), want)
+	}
+	if d := time.Since(began); d > time.Second {
+		t.Errorf("Fetch took %v; the stuck source should time out after 50ms", d)
+	}
+}
+
+func TestFetchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := newTestEngine(&fakeSource{id: "fast"}).Fetch(ctx, time.Time{}, time.Time{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestFetchConcurrency(t *testing.T) {
+	var active, peak atomic.Int32
+	var sources []source.Source
+	for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		sources = append(sources, &fakeSource{id: id, delay: 20 * time.Millisecond, active: &active, peak: &peak})
+	}
+	e := newTestEngine(sources...)
+	e.Concurrency = 2
+
+	got, err := e.Fetch(context.Background(), time.Time{}, time.Time{})
+	if err != nil || len(got) != len(sources) {
+		t.Fatalf("got %d events, %v; want %d", len(got), err, len(sources))
+	}
+	if p := peak.Load(); p != 2 {
+		t.Errorf("%d sources fetched at once, want 2", p)
+	}
+}
+
+func TestFetchOrder(t *testing.T) {
+	// Later sources answer first; the output still follows source order.
+	var sources []source.Source
+	var want []string
+	for i, id := range []string{"a", "b", "c", "d"} {
+		sources = append(sources, &fakeSource{id: id, delay: time.Duration(4-i) * 5 * time.Millisecond})
+		want = append(want, "Event from "+id)
+	}
+	from := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	for range 5 {
+		got, err := newTestEngine(sources...).Fetch(context.Background(), from, from.Add(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(names(got), want) {
+			t.Fatalf("got %v, want %v", names(got), want)
+		}
+	}
+}
+
+// detailSource serves detail pages by event URL.
+type detailSource struct {
+	id      string
+	details map[string]source.Details
+}
+
+func (s *detailSource) ID() string { return s.id }
+
+func (s *detailSource) Fetch(context.Context, time.Time, time.Time) ([]model.Event, error) {
+	return nil, nil
+}
+
+func (s *detailSource) FetchDetails(ctx context.Context, e model.Event) (source.Details, error) {
+	d, ok := s.details[e.URL]
+	if !ok {
+		return source.Details{}, errors.New("HTTP 404")
+	}
+	return d, nil
+}
+
+func TestEnrich(t *testing.T) {
+	start := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	src := &detailSource{id: "leipzig.de", details: map[string]source.Details{
+		"https://www.leipzig.de/a": {Address: "Koburger Straße 3", Price: "25 €"},
+		"https://www.leipzig.de/b": {Price: "frei"},
+	}}
+	events := []model.Event{
+		{Name: "A", Source: "leipzig.de", URL: "https://www.leipzig.de/a", StartTime: start},
+		{Name: "B", Source: "leipzig.de", URL: "https://www.leipzig.de/b", Price: "12 €", StartTime: start},
+		{Name: "C", Source: "leipzig.de", URL: "https://www.leipzig.de/gone", StartTime: start},
+		// Another source's event is not sent to leipzig.de's detail pages.
+		{Name: "D", Source: "prinz.de", URL: "https://www.leipzig.de/a", StartTime: start},
+	}
+
+	New(src).enrich(context.Background(), events)
+
+	if events[0].Address != "Koburger Straße 3" || events[0].Price != "25 €" {
+		t.Errorf("A not enriched: %+v", events[0])
+	}
+	if events[1].Price != "12 €" {
+		t.Errorf("B price = %q, want the listing's own", events[1].Price)
+	}
+	if events[2].Price != "" || events[3].Address != "" {
+		t.Errorf("failed or foreign events changed: %+v, %+v", events[2], events[3])
+	}
+}
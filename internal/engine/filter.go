@@ -1,20 +1,47 @@
 package engine
 
 import (
+	"math"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/havocked/leipzig-cli/internal/district"
+	"github.com/havocked/leipzig-cli/internal/geo"
 	"github.com/havocked/leipzig-cli/internal/model"
 )
 
 type FilterOptions struct {
 	Category string
+	// District keeps events in this Ortsteil or Stadtbezirk.
+	District string
 	Search   string
 	Tags     []string
+	// Free keeps events known to be free; MaxPrice keeps events whose
+	// cheapest regular ticket costs at most that (nil = any price).
 	Free     bool
-	From     time.Time
-	To       time.Time
-	Limit    int
+	MaxPrice *float64
+	// From and To keep events that take place at any time in [From, To),
+	// including ones that started earlier.
+	From time.Time
+	To   time.Time
+	// StartAfter drops events that start before it (--after), even ones
+	// still in progress then.
+	StartAfter time.Time
+	Limit      int
+	// At keeps events in progress at that instant (--happening-now).
+	At time.Time
+	// Ongoing is what to do with multi-day events: "include" (default),
+	// "exclude" or "only".
+	Ongoing string
+
+	// Near keeps events within Radius meters of it, skipping events
+	// without coordinates, and sets their DistanceMeters.
+	Near   geo.Point
+	Radius float64
+	// Sort is "time" (default, keeps the input order), "distance" or
+	// "price" (cheapest first, unknown prices last).
+	Sort string
 }
 
 func Filter(events []model.Event, opts FilterOptions) []model.Event {
@@ -35,12 +62,29 @@ func Filter(events []model.Event, opts FilterOptions) []model.Event {
 			}
 		}
 
-		if !opts.From.IsZero() && e.StartTime.Before(opts.From) {
+		if opts.District != "" && !district.Within(e.District, opts.District) {
 			continue
 		}
-		if !opts.To.IsZero() && e.StartTime.After(opts.To) {
+
+		if !e.Overlaps(opts.From, opts.To) {
 			continue
 		}
+		if e.StartTime.Before(opts.StartAfter) {
+			continue
+		}
+		if !opts.At.IsZero() && !inProgress(e, opts.At) {
+			continue
+		}
+		switch opts.Ongoing {
+		case "exclude":
+			if e.MultiDay() {
+				continue
+			}
+		case "only":
+			if !e.MultiDay() {
+				continue
+			}
+		}
 
 		if opts.Search != "" {
 			q := strings.ToLower(opts.Search)
@@ -50,9 +94,11 @@ func Filter(events []model.Event, opts FilterOptions) []model.Event {
 			}
 		}
 
-		if opts.Free {
-			p := strings.ToLower(e.Price)
-			if p != "free" && p != "frei" && p != "kostenlos" && p != "0€" && p != "" {
+		if opts.Free && pricing(e).Status != model.PriceFree {
+			continue
+		}
+		if opts.MaxPrice != nil {
+			if p, ok := pricing(e).Cheapest(); !ok || p > *opts.MaxPrice {
 				continue
 			}
 		}
@@ -71,12 +117,70 @@ func Filter(events []model.Event, opts FilterOptions) []model.Event {
 			}
 		}
 
-		result = append(result, e)
-
-		if opts.Limit > 0 && len(result) >= opts.Limit {
-			break
+		if !opts.Near.IsZero() {
+			if e.Lat == 0 && e.Lon == 0 {
+				continue
+			}
+			d := geo.Distance(opts.Near, geo.Point{Lat: e.Lat, Lon: e.Lon})
+			if opts.Radius > 0 && d > opts.Radius {
+				continue
+			}
+			m := int(math.Round(d))
+			e.DistanceMeters = &m
 		}
+
+		result = append(result, e)
 	}
 
+	switch opts.Sort {
+	case "distance":
+		sort.SliceStable(result, func(i, j int) bool {
+			return distance(result[i]) < distance(result[j])
+		})
+	case "price":
+		sort.SliceStable(result, func(i, j int) bool {
+			return price(result[i]) < price(result[j])
+		})
+	}
+	if opts.Limit > 0 && len(result) > opts.Limit {
+		result = result[:opts.Limit]
+	}
 	return result
 }
+
+// distance returns e.DistanceMeters, with unknown distances last.
+func distance(e model.Event) int {
+	if e.DistanceMeters == nil {
+		return math.MaxInt
+	}
+	return *e.DistanceMeters
+}
+
+// pricing returns e.Pricing, parsing e.Price if the engine hasn't.
+func pricing(e model.Event) model.Pricing {
+	if e.Pricing != nil {
+		return *e.Pricing
+	}
+	return model.ParsePrice(e.Price)
+}
+
+// price returns the cheapest regular ticket price, with unknown prices last.
+func price(e model.Event) float64 {
+	if p, ok := pricing(e).Cheapest(); ok {
+		return p
+	}
+	return math.Inf(1)
+}
+
+// assumedDuration is how long an event without an end time is taken to
+// last when checking whether it is in progress.
+const assumedDuration = 2 * time.Hour
+
+// inProgress reports whether e is running at t.
+func inProgress(e model.Event, t time.Time) bool {
+	end := e.End()
+	if end.Equal(e.StartTime) {
+		end = e.StartTime.Add(assumedDuration)
+	}
+	return !e.StartTime.After(t) && t.Before(end)
+}
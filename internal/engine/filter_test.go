@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/geo"
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+func TestFilterNear(t *testing.T) {
+	start := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	events := []model.Event{
+		{Name: "Gewandhaus", StartTime: start, Lat: 51.3378, Lon: 12.3808},
+		{Name: "Werk 2", StartTime: start, Lat: 51.3108, Lon: 12.3758},
+		{Name: "Haus Auensee", StartTime: start, Lat: 51.3719, Lon: 12.3107},
+		{Name: "Conne Island", StartTime: start.Add(time.Hour), Lat: 51.3106, Lon: 12.3893},
+		{Name: "Unknown venue", StartTime: start},
+	}
+	kreuz := geo.Point{Lat: 51.3119, Lon: 12.3803}
+
+	got := Filter(events, FilterOptions{Near: kreuz, Radius: 3500, Sort: "distance"})
+	var names []string
+	for _, e := range got {
+		names = append(names, e.Name)
+	}
+	want := []string{"Werk 2", "Conne Island", "Gewandhaus"}
+	if len(names) != len(want) {
+		t.Fatalf("got %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got %v, want %v", names, want)
+		}
+	}
+	if d := got[0].DistanceMeters; d == nil || *d < 300 || *d > 400 {
+		t.Errorf("Werk 2 DistanceMeters = %v, want about 350", d)
+	}
+	if events[1].DistanceMeters != nil {
+		t.Error("Filter modified its input")
+	}
+
+	if got := Filter(events, FilterOptions{Near: kreuz, Radius: 3500, Limit: 1}); len(got) != 1 || got[0].Name != "Gewandhaus" {
+		t.Errorf("time order with limit: %v", got)
+	}
+}
+
+func TestFilterDistrict(t *testing.T) {
+	events := []model.Event{
+		{Name: "Conne Island", District: "Connewitz"},
+		{Name: "Werk 2", District: "Connewitz"},
+		{Name: "naTo", District: "Südvorstadt"},
+		{Name: "Gewandhaus", District: "Zentrum"},
+		{Name: "Unknown venue"},
+	}
+	for d, want := range map[string]int{"Connewitz": 2, "Süd": 3, "Mitte": 1, "Plagwitz": 0} {
+		if got := Filter(events, FilterOptions{District: d}); len(got) != want {
+			t.Errorf("District %s: %d events, want %d", d, len(got), want)
+		}
+	}
+}
+
+func TestFilterPrice(t *testing.T) {
+	events := []model.Event{
+		{Name: "Unknown"},
+		{Name: "Concert", Price: "VVK 18 € / AK 22 €"},
+		{Name: "Reading", Price: "Eintritt frei"},
+		{Name: "Theater", Price: "12 €, ermäßigt 8 €"},
+		{Name: "Jam", Price: "Spende"},
+	}
+	names := func(events []model.Event) []string {
+		var result []string
+		for _, e := range events {
+			result = append(result, e.Name)
+		}
+		return result
+	}
+
+	if got := names(Filter(events, FilterOptions{Free: true})); !slices.Equal(got, []string{"Reading"}) {
+		t.Errorf("Free: %v", got)
+	}
+	limit := 15.0
+	if got := names(Filter(events, FilterOptions{MaxPrice: &limit})); !slices.Equal(got, []string{"Reading", "Theater", "Jam"}) {
+		t.Errorf("MaxPrice 15: %v", got)
+	}
+	want := []string{"Reading", "Jam", "Theater", "Concert", "Unknown"}
+	if got := names(Filter(events, FilterOptions{Sort: "price"})); !slices.Equal(got, want) {
+		t.Errorf("Sort price: %v, want %v", got, want)
+	}
+}
+
+func TestFilterOngoing(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	sat := time.Date(2026, 10, 17, 0, 0, 0, 0, loc)
+	events := []model.Event{
+		{Name: "Exhibition", StartTime: time.Date(2026, 9, 1, 0, 0, 0, 0, loc), EndTime: time.Date(2026, 11, 30, 0, 0, 0, 0, loc)},
+		{Name: "Concert", StartTime: sat.Add(20 * time.Hour), EndTime: sat.Add(22 * time.Hour)},
+		{Name: "Party", StartTime: sat.Add(23 * time.Hour)},
+		{Name: "Closed", StartTime: time.Date(2026, 9, 1, 0, 0, 0, 0, loc), EndTime: time.Date(2026, 10, 16, 0, 0, 0, 0, loc)},
+	}
+	names := func(opts FilterOptions) []string {
+		opts.From, opts.To = sat, sat.Add(48*time.Hour)
+		var result []string
+		for _, e := range Filter(events, opts) {
+			result = append(result, e.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		opts FilterOptions
+		want []string
+	}{
+		{FilterOptions{}, []string{"Exhibition", "Concert", "Party"}},
+		{FilterOptions{Ongoing: "exclude"}, []string{"Concert", "Party"}},
+		{FilterOptions{Ongoing: "only"}, []string{"Exhibition"}},
+		{FilterOptions{At: sat.Add(21 * time.Hour)}, []string{"Exhibition", "Concert"}},
+		{FilterOptions{At: sat.Add(24 * time.Hour)}, []string{"Exhibition", "Party"}},
+	}
+	for _, tt := range tests {
+		if got := names(tt.opts); !slices.Equal(got, tt.want) {
+			t.Errorf("%+v: got %v, want %v", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestFilterStartAfter(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	sat := time.Date(2026, 10, 17, 0, 0, 0, 0, loc)
+	events := []model.Event{
+		{Name: "Flea market", StartTime: sat},
+		{Name: "Workshop", StartTime: sat.Add(14 * time.Hour), EndTime: sat.Add(18 * time.Hour)},
+		{Name: "Reading", StartTime: sat.Add(14 * time.Hour)},
+		{Name: "Concert", StartTime: sat.Add(16 * time.Hour)},
+		{Name: "Party", StartTime: sat.Add(23 * time.Hour)},
+	}
+	var got []string
+	for _, e := range Filter(events, FilterOptions{From: sat, To: sat.Add(24 * time.Hour), StartAfter: sat.Add(16 * time.Hour)}) {
+		got = append(got, e.Name)
+	}
+	if want := []string{"Concert", "Party"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
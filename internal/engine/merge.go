@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+// DefaultPriority prefers leipzig.de, the city's own listing, for every field.
+var DefaultPriority = Priority{Sources: []string{"leipzig.de"}}
+
+// Priority decides which duplicate supplies each field of a merged event.
+// For every field, the value from the source listed first wins; when
+// neither source is listed (or both are the same), the richer value wins:
+// the longer text, a start time with a time of day, a known end time, a
+// category other than "other". Empty values never win, and placeholders
+// (a start at midnight, i.e. only a date) lose to a time of day from any
+// source. Start and end time are one field ("startTime"), so an end is
+// never paired with another listing's start.
+type Priority struct {
+	// Sources orders source IDs for every field.
+	Sources []string
+	// Fields overrides Sources for single fields, keyed by JSON name
+	// ("description", "price", "imageUrl").
+	Fields map[string][]string
+}
+
+// Validate checks that Fields only names fields that are merged.
+func (p Priority) Validate() error {
+	for name := range p.Fields {
+		if !slices.ContainsFunc(mergeFields, func(f mergeField) bool { return f.name == name }) {
+			return fmt.Errorf("merge: unknown field %q", name)
+		}
+	}
+	return nil
+}
+
+// rank returns the position of source in the field's priority list, or -1.
+func (p Priority) rank(field, source string) int {
+	order, ok := p.Fields[field]
+	if !ok {
+		order = p.Sources
+	}
+	return slices.Index(order, source)
+}
+
+// prefer reports whether value b from source sb beats value a from sa.
+// size is the richness of each value; 0 means empty.
+func (p Priority) prefer(field, sa, sb string, a, b int) bool {
+	if b == 0 || a == 0 {
+		return a == 0 && b > 0
+	}
+	ra, rb := p.rank(field, sa), p.rank(field, sb)
+	switch {
+	case ra == rb:
+		return b > a
+	case ra < 0:
+		return true
+	case rb < 0:
+		return false
+	}
+	return rb < ra
+}
+
+// mergeField is one event field that can come from either duplicate.
+type mergeField struct {
+	name string
+	size func(e *model.Event) int
+	copy func(dst, src *model.Event)
+	// placeholder is the size up to which a value is only a stand-in that
+	// any richer value replaces, whatever the source priority.
+	placeholder int
+}
+
+func stringField(name string, f func(e *model.Event) *string) mergeField {
+	return mergeField{
+		name: name,
+		size: func(e *model.Event) int { return len(*f(e)) },
+		copy: func(dst, src *model.Event) { *f(dst) = *f(src) },
+	}
+}
+
+// mergeFields lists the merged fields. URL and Source stay with the base
+// event so detail pages are read by the source they belong to.
+var mergeFields = []mergeField{
+	stringField("name", func(e *model.Event) *string { return &e.Name }),
+	stringField("description", func(e *model.Event) *string { return &e.Description }),
+	{
+		// The end goes with the start it was listed with. A time of day
+		// outweighs any end; a date-only start is a placeholder.
+		name: "startTime",
+		size: func(e *model.Event) int { return 3*timeSize(e.StartTime) + timeSize(e.EndTime) },
+		copy: func(dst, src *model.Event) {
+			dst.StartTime, dst.EndTime = src.StartTime, src.EndTime
+			setEndSource(dst, src)
+		},
+		placeholder: 5,
+	},
+	{
+		// The registry match goes with the venue text it came from.
+		name: "venue",
+		size: func(e *model.Event) int { return len(e.Venue) },
+		copy: func(dst, src *model.Event) {
+			dst.Venue, dst.VenueID, dst.Lat, dst.Lon = src.Venue, src.VenueID, src.Lat, src.Lon
+			dst.District = src.District
+		},
+	},
+	stringField("address", func(e *model.Event) *string { return &e.Address }),
+	{
+		name: "category",
+		size: func(e *model.Event) int {
+			if e.Category == "" || e.Category == model.CategoryOther {
+				return 0
+			}
+			return 1
+		},
+		copy: func(dst, src *model.Event) { dst.Category = src.Category },
+	},
+	stringField("price", func(e *model.Event) *string { return &e.Price }),
+	stringField("organizer", func(e *model.Event) *string { return &e.Organizer }),
+	stringField("imageUrl", func(e *model.Event) *string { return &e.ImageURL }),
+}
+
+// timeSize scores a time: zero is empty, midnight usually means the source
+// only knew the date.
+func timeSize(t time.Time) int {
+	switch {
+	case t.IsZero():
+		return 0
+	case t.Hour() == 0 && t.Minute() == 0:
+		return 1
+	}
+	return 2
+}
+
+// setEndSource records in dst.FieldSources that its end time came from src.
+func setEndSource(dst, src *model.Event) {
+	delete(dst.FieldSources, "endTime")
+	if !src.EndTime.IsZero() {
+		dst.FieldSources["endTime"] = fieldSource(src, "endTime")
+	}
+}
+
+// fieldSource returns the source that supplied e's value for field.
+func fieldSource(e *model.Event, field string) string {
+	if s, ok := e.FieldSources[field]; ok {
+		return s
+	}
+	return e.Source
+}
+
+// base orders two duplicates so the one whose Source and URL the merged
+// event keeps (by source priority, then richness) comes first.
+func (p Priority) base(a, b model.Event) (model.Event, model.Event) {
+	if p.prefer("", a.Source, b.Source, richness(a)+1, richness(b)+1) {
+		return b, a
+	}
+	return a, b
+}
+
+// merge combines two duplicates. The base event keeps its Source and URL;
+// every other field is picked separately, tags are united and FieldSources
+// records where each value came from.
+func (p Priority) merge(a, b model.Event) model.Event {
+	a, b = p.base(a, b)
+
+	m := a
+	m.FieldSources = make(map[string]string, len(mergeFields))
+	for _, f := range mergeFields {
+		if f.size(&a) > 0 {
+			m.FieldSources[f.name] = fieldSource(&a, f.name)
+		}
+	}
+	setEndSource(&m, &a)
+	for _, f := range mergeFields {
+		sa, sb := fieldSource(&a, f.name), fieldSource(&b, f.name)
+		size, other := f.size(&a), f.size(&b)
+		take := p.prefer(f.name, sa, sb, size, other)
+		switch {
+		case size <= f.placeholder && other > size:
+			take = true
+		case other <= f.placeholder && size > other:
+			take = false
+		}
+		if take {
+			f.copy(&m, &b)
+			m.FieldSources[f.name] = sb
+		}
+	}
+
+	// A duplicate listing the same start can fill in a missing end.
+	if m.EndTime.IsZero() {
+		for _, e := range []*model.Event{&a, &b} {
+			if !e.EndTime.IsZero() && e.StartTime.Equal(m.StartTime) {
+				m.EndTime = e.EndTime
+				setEndSource(&m, e)
+				break
+			}
+		}
+	}
+
+	m.Tags = slices.Clip(m.Tags)
+	for _, t := range b.Tags {
+		if !slices.Contains(m.Tags, t) {
+			m.Tags = append(m.Tags, t)
+		}
+	}
+	for _, ref := range b.Sources {
+		m.AddSource(ref)
+	}
+	return m
+}
@@ -0,0 +1,202 @@
+package geo
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Address is one entry of an address dataset.
+type Address struct {
+	Street      string
+	HouseNumber string
+	Postcode    string
+	Ortsteil    string
+	Point
+}
+
+func (a Address) String() string {
+	s := strings.TrimSpace(a.Street + " " + a.HouseNumber)
+	if a.Postcode != "" {
+		s += ", " + a.Postcode + " Leipzig"
+	}
+	return s
+}
+
+// addressColumns maps the header names found in address CSVs (our own,
+// the city's open data, common GIS exports) to Address fields.
+var addressColumns = map[string]string{
+	"street": "street", "strasse": "street", "straße": "street", "str": "street", "strassenname": "street", "addr:street": "street",
+	"housenumber": "number", "hausnummer": "number", "hnr": "number", "hausnr": "number", "addr:housenumber": "number",
+	"hausnummerzusatz": "suffix", "zusatz": "suffix",
+	"postcode": "postcode", "plz": "postcode", "postleitzahl": "postcode", "addr:postcode": "postcode",
+	"ortsteil": "ortsteil", "ot": "ortsteil", "ortsteilname": "ortsteil", "district": "ortsteil", "addr:suburb": "ortsteil",
+	"lat": "lat", "latitude": "lat", "breite": "lat", "y": "lat",
+	"lon": "lon", "lng": "lon", "longitude": "lon", "laenge": "lon", "länge": "lon", "x": "lon",
+}
+
+// LoadAddresses reads an address dataset: an OpenStreetMap extract if the
+// name ends in .osm or .xml, CSV otherwise. A missing file is not an error.
+func LoadAddresses(path string) ([]Address, error) {
+	f, err := os.Open(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("geo: %w", err)
+	}
+	defer f.Close()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".osm", ".xml":
+		return ReadOSM(f)
+	default:
+		return ReadAddressCSV(f)
+	}
+}
+
+// ReadAddressCSV reads addresses from a CSV file with a header row. Comma
+// and semicolon separators and decimal commas are accepted; columns are
+// found by name (street/Straße, housenumber/Hausnummer, postcode/PLZ,
+// ortsteil, lat, lon). Rows without a street or coordinates are skipped.
+func ReadAddressCSV(r io.Reader) ([]Address, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("geo: read addresses: %w", err)
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	header, _, _ := strings.Cut(text, "\n")
+
+	cr := csv.NewReader(strings.NewReader(text))
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		cr.Comma = ';'
+	}
+
+	head, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("geo: read addresses: %w", err)
+	}
+	col := make(map[string]int)
+	for i, h := range head {
+		if f, ok := addressColumns[strings.ToLower(strings.TrimSpace(h))]; ok {
+			if _, dup := col[f]; !dup {
+				col[f] = i
+			}
+		}
+	}
+	for _, f := range []string{"street", "lat", "lon"} {
+		if _, ok := col[f]; !ok {
+			return nil, fmt.Errorf("geo: read addresses: no %s column in header %q", f, strings.Join(head, ","))
+		}
+	}
+
+	var addrs []Address
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return addrs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("geo: read addresses: %w", err)
+		}
+		get := func(f string) string {
+			if i, ok := col[f]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		lat, err1 := parseCoord(get("lat"))
+		lon, err2 := parseCoord(get("lon"))
+		if err1 != nil || err2 != nil || get("street") == "" || !valid(Point{lat, lon}) {
+			continue
+		}
+		addrs = append(addrs, Address{
+			Street:      get("street"),
+			HouseNumber: get("number") + get("suffix"),
+			Postcode:    get("postcode"),
+			Ortsteil:    get("ortsteil"),
+			Point:       Point{lat, lon},
+		})
+	}
+}
+
+// valid rejects projected coordinates (UTM and friends) and empty points.
+func valid(p Point) bool {
+	return !p.IsZero() && p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
+
+func parseCoord(s string) (float64, error) {
+	return strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+}
+
+// ReadOSM reads the addressed nodes (addr:street with coordinates) from an
+// OpenStreetMap XML extract. Ways and relations are skipped: their
+// coordinates would need the referenced nodes.
+func ReadOSM(r io.Reader) ([]Address, error) {
+	dec := xml.NewDecoder(r)
+	var addrs []Address
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return addrs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("geo: read OSM: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "node" {
+			continue
+		}
+		var node struct {
+			Lat  float64 `xml:"lat,attr"`
+			Lon  float64 `xml:"lon,attr"`
+			Tags []struct {
+				K string `xml:"k,attr"`
+				V string `xml:"v,attr"`
+			} `xml:"tag"`
+		}
+		if err := dec.DecodeElement(&node, &start); err != nil {
+			return nil, fmt.Errorf("geo: read OSM: %w", err)
+		}
+		a := Address{Point: Point{node.Lat, node.Lon}}
+		for _, t := range node.Tags {
+			switch t.K {
+			case "addr:street":
+				a.Street = t.V
+			case "addr:housenumber":
+				a.HouseNumber = t.V
+			case "addr:postcode":
+				a.Postcode = t.V
+			case "addr:suburb":
+				a.Ortsteil = t.V
+			}
+		}
+		if a.Street != "" && !a.Point.IsZero() {
+			addrs = append(addrs, a)
+		}
+	}
+}
+
+// WriteAddresses writes addrs as CSV in the format ReadAddressCSV reads.
+func WriteAddresses(w io.Writer, addrs []Address) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"street", "housenumber", "postcode", "ortsteil", "lat", "lon"})
+	for _, a := range addrs {
+		cw.Write([]string{
+			a.Street, a.HouseNumber, a.Postcode, a.Ortsteil,
+			strconv.FormatFloat(a.Lat, 'f', 6, 64), strconv.FormatFloat(a.Lon, 'f', 6, 64),
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("geo: write addresses: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package geo
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//go:embed gazetteer.csv
+var builtinGazetteer string
+
+// Place is a named point in the gazetteer.
+type Place struct {
+	Name string `json:"name"`
+	// Kind is "place", "address", "street" (a street's midpoint, used for
+	// addresses the gazetteer doesn't know) or "cached" (an earlier online
+	// lookup).
+	Kind     string `json:"kind"`
+	Ortsteil string `json:"ortsteil,omitempty"`
+	Point
+}
+
+// Gazetteer finds places, addresses and streets by name without going
+// online. Street names are matched fuzzily, so "Karl-Liebknechtstr." and
+// "Karl Liebknecht Strasse" find the same street.
+type Gazetteer struct {
+	byKey   map[string]Place   // placeKey -> place
+	streets map[string]*street // streetID -> street
+}
+
+// street collects the known addresses on one street.
+type street struct {
+	name   string
+	houses map[string]Place // house number -> address
+	// point is the listed midpoint; once addresses are known, their mean
+	// is used instead.
+	point     Point
+	sum       Point
+	ortsteile map[string]int
+}
+
+func (s *street) place() Place {
+	p := Place{Name: s.name, Kind: "street", Point: s.point}
+	if n := len(s.houses); n > 0 {
+		p.Point = Point{s.sum.Lat / float64(n), s.sum.Lon / float64(n)}
+	}
+	best := 0
+	for o, c := range s.ortsteile {
+		if c > best || c == best && o < p.Ortsteil {
+			p.Ortsteil, best = o, c
+		}
+	}
+	return p
+}
+
+// NewGazetteer returns a gazetteer of the built-in Leipzig places plus extra.
+func NewGazetteer(extra ...Place) *Gazetteer {
+	g := &Gazetteer{byKey: make(map[string]Place), streets: make(map[string]*street)}
+	places, err := readPlaces(strings.NewReader(builtinGazetteer))
+	if err != nil {
+		panic(err)
+	}
+	for _, p := range append(places, extra...) {
+		g.Add(p)
+	}
+	return g
+}
+
+// Add adds a place, replacing any with the same name, and lets Lookup
+// find it under aliases too. A place of kind "street" is also used for
+// addresses on that street.
+func (g *Gazetteer) Add(p Place, aliases ...string) {
+	k := placeKey(p.Name)
+	if k == "" {
+		return
+	}
+	g.byKey[k] = p
+	for _, a := range aliases {
+		if ak := placeKey(a); ak != "" {
+			g.byKey[ak] = p
+		}
+	}
+	if p.Kind == "street" {
+		s := g.street(k, p.Name)
+		s.point = p.Point
+		if p.Ortsteil != "" {
+			s.ortsteile[p.Ortsteil]++
+		}
+	}
+}
+
+// AddAddresses adds an address dataset (see ReadAddresses).
+func (g *Gazetteer) AddAddresses(addrs ...Address) {
+	for _, a := range addrs {
+		k := placeKey(a.Street)
+		if k == "" || a.Point.IsZero() {
+			continue
+		}
+		s := g.street(k, a.Street)
+		hn := houseKey(a.HouseNumber)
+		if old, ok := s.houses[hn]; ok {
+			s.sum.Lat -= old.Lat
+			s.sum.Lon -= old.Lon
+		}
+		s.houses[hn] = Place{Name: a.String(), Kind: "address", Ortsteil: a.Ortsteil, Point: a.Point}
+		s.sum.Lat += a.Lat
+		s.sum.Lon += a.Lon
+		if a.Ortsteil != "" {
+			s.ortsteile[a.Ortsteil]++
+		}
+	}
+}
+
+func (g *Gazetteer) street(key, name string) *street {
+	id := streetID(key)
+	s, ok := g.streets[id]
+	if !ok {
+		s = &street{name: name, houses: make(map[string]Place), ortsteile: make(map[string]int)}
+		g.streets[id] = s
+	}
+	return s
+}
+
+// Lookup resolves a place name, venue or street address. An address the
+// gazetteer doesn't know resolves to its street's midpoint, or to the
+// square it is on.
+func (g *Gazetteer) Lookup(query string) (Place, bool) {
+	k := placeKey(query)
+	if k == "" {
+		return Place{}, false
+	}
+	if p, ok := g.byKey[k]; ok && p.Kind != "street" {
+		return p, true
+	}
+
+	name, number := splitAddress(k)
+	if s := g.findStreet(name); s != nil {
+		if p, ok := s.houses[houseKey(number)]; ok && number != "" {
+			return p, true
+		}
+		if p, ok := s.houses[firstNumber(number)]; ok && number != "" {
+			return p, true
+		}
+		return s.place(), true
+	}
+	if p, ok := g.byKey[name]; ok && number != "" {
+		return p, true
+	}
+	return Place{}, false
+}
+
+// findStreet returns the street named name, or the only close spelling.
+func (g *Gazetteer) findStreet(name string) *street {
+	id := streetID(name)
+	if s, ok := g.streets[id]; ok {
+		return s
+	}
+	if len(id) < 6 {
+		return nil
+	}
+	maxDist := max(1, len(id)/8)
+	var best *street
+	bestDist, ties := maxDist+1, 0
+	for other, s := range g.streets {
+		if d := levenshtein(id, other, maxDist); d < bestDist {
+			best, bestDist, ties = s, d, 1
+		} else if d == bestDist && d <= maxDist {
+			ties++
+		}
+	}
+	if ties != 1 {
+		return nil
+	}
+	return best
+}
+
+// readPlaces parses "name,kind,lat,lon" lines; # starts a comment.
+func readPlaces(r io.Reader) ([]Place, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 4
+	var places []Place
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return places, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("geo: gazetteer: %w", err)
+		}
+		lat, err1 := strconv.ParseFloat(rec[2], 64)
+		lon, err2 := strconv.ParseFloat(rec[3], 64)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("geo: gazetteer: bad coordinates for %q", rec[0])
+		}
+		places = append(places, Place{Name: rec[0], Kind: rec[1], Point: Point{lat, lon}})
+	}
+}
+
+var (
+	postcode    = regexp.MustCompile(`\b0\d{4}\b`)
+	houseNumber = regexp.MustCompile(`\s(\d+\s?[a-z]?(\s?[-/]\s?\d+\s?[a-z]?)?)$`)
+)
+
+// placeKey normalizes a place name or address: lowercase, umlauts folded,
+// "Str." and "Straße" spelled "strasse", postcode and "Leipzig" dropped.
+func placeKey(s string) string {
+	s = strings.ToLower(postcode.ReplaceAllString(s, " "))
+	s = strings.NewReplacer("ä", "a", "ö", "o", "ü", "u", "ß", "ss").Replace(s)
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '/'
+	})
+	kept := fields[:0]
+	for _, f := range fields {
+		switch {
+		case f == "leipzig":
+			continue
+		case f == "str" || f == "strasse":
+			if n := len(kept); n > 0 && strings.HasSuffix(kept[n-1], "-") {
+				kept[n-1] += "strasse"
+				continue
+			}
+			f = "strasse"
+		case strings.HasSuffix(f, "str"):
+			f = strings.TrimSuffix(f, "str") + "strasse"
+		}
+		kept = append(kept, f)
+	}
+	return strings.Join(kept, " ")
+}
+
+// splitAddress splits a placeKey into street and house number.
+func splitAddress(k string) (name, number string) {
+	m := houseNumber.FindStringSubmatchIndex(" " + k)
+	if m == nil {
+		return k, ""
+	}
+	return strings.TrimSpace(k[:m[0]]), k[m[2]-1:]
+}
+
+// streetID reduces a street name to letters and digits, so hyphens and
+// spaces don't matter.
+func streetID(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, name)
+}
+
+// houseKey normalizes a house number: "12 A" -> "12a".
+func houseKey(n string) string {
+	return strings.ToLower(strings.ReplaceAll(n, " ", ""))
+}
+
+// firstNumber returns the first house of a range: "5-11" -> "5".
+func firstNumber(n string) string {
+	if i := strings.IndexAny(n, "-/"); i > 0 {
+		return houseKey(n[:i])
+	}
+	return houseKey(n)
+}
+
+// levenshtein returns the edit distance between a and b, or limit+1 once
+// it is known to exceed limit.
+func levenshtein(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > limit || -d > limit {
+		return limit + 1
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,75 @@
+// Package geo resolves places in Leipzig to coordinates and measures
+// distances between them. Lookups use a local gazetteer and the on-disk
+// cache first, so they work offline for known places.
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// earthRadius is the mean Earth radius in meters.
+const earthRadius = 6371000
+
+// Point is a WGS84 coordinate.
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// IsZero reports whether p is unset.
+func (p Point) IsZero() bool { return p.Lat == 0 && p.Lon == 0 }
+
+func (p Point) String() string { return fmt.Sprintf("%.5f,%.5f", p.Lat, p.Lon) }
+
+// Distance returns the great-circle distance between a and b in meters.
+func Distance(a, b Point) float64 {
+	rad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := rad(b.Lat - a.Lat)
+	dLon := rad(b.Lon - a.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(a.Lat))*math.Cos(rad(b.Lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// ParsePoint parses "lat,lon", e.g. "51.3119,12.3803".
+func ParsePoint(s string) (Point, bool) {
+	latText, lonText, ok := strings.Cut(s, ",")
+	if !ok {
+		return Point{}, false
+	}
+	lat, err1 := strconv.ParseFloat(strings.TrimSpace(latText), 64)
+	lon, err2 := strconv.ParseFloat(strings.TrimSpace(lonText), 64)
+	if err1 != nil || err2 != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return Point{}, false
+	}
+	return Point{lat, lon}, true
+}
+
+// ParseRadius parses a distance such as "2km", "1.5 km", "800m" or "800"
+// (meters) and returns it in meters.
+func ParseRadius(s string) (float64, error) {
+	t := strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	unit := 1.0
+	switch {
+	case strings.HasSuffix(t, "km"):
+		t, unit = strings.TrimSuffix(t, "km"), 1000
+	case strings.HasSuffix(t, "m"):
+		t = strings.TrimSuffix(t, "m")
+	}
+	v, err := strconv.ParseFloat(t, 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("geo: invalid radius %q (expected e.g. 2km or 800m)", s)
+	}
+	return v * unit, nil
+}
+
+// FormatDistance renders meters for people: "350 m", "1.2 km".
+func FormatDistance(m float64) string {
+	if m < 1000 {
+		return fmt.Sprintf("%.0f m", m)
+	}
+	return fmt.Sprintf("%.1f km", m/1000)
+}
@@ -0,0 +1,165 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/havocked/leipzig-cli/internal/cache"
+)
+
+func TestDistance(t *testing.T) {
+	hbf := Point{51.3455, 12.3821}
+	kreuz := Point{51.3119, 12.3803}
+	if d := Distance(hbf, kreuz); math.Abs(d-3740) > 30 {
+		t.Errorf("Distance = %.0f m, want about 3740 m", d)
+	}
+	if d := Distance(hbf, hbf); d != 0 {
+		t.Errorf("Distance to itself = %f", d)
+	}
+}
+
+func TestParse(t *testing.T) {
+	if p, ok := ParsePoint(" 51.32, 12.37"); !ok || p != (Point{51.32, 12.37}) {
+		t.Errorf("ParsePoint = %v, %v", p, ok)
+	}
+	for _, s := range []string{"Südplatz", "51.32", "91,12", "a,b"} {
+		if _, ok := ParsePoint(s); ok {
+			t.Errorf("ParsePoint(%q) succeeded", s)
+		}
+	}
+
+	radii := map[string]float64{"2km": 2000, "1.5 km": 1500, "800m": 800, "800": 800}
+	for s, want := range radii {
+		if got, err := ParseRadius(s); err != nil || got != want {
+			t.Errorf("ParseRadius(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseRadius("far"); err == nil {
+		t.Error("ParseRadius(far) succeeded")
+	}
+}
+
+func TestGazetteerLookup(t *testing.T) {
+	g := NewGazetteer(Place{Name: "Koburger Straße 3, 04277 Leipzig", Kind: "address", Point: Point{51.3106, 12.3893}})
+	tests := map[string]string{
+		"Connewitzer Kreuz":                  "Connewitzer Kreuz",
+		"connewitz kreuz":                    "Connewitz Kreuz",
+		"Karl-Liebknecht-Str. 100":           "Karl-Liebknecht-Straße",
+		"Karl-Liebknecht-Straße 46, Leipzig": "Karl-Liebknecht-Straße",
+		"Bornaische Str. 12a":                "Bornaische Straße",
+		"Koburger Str. 3, 04277 Leipzig":     "Koburger Straße 3, 04277 Leipzig",
+		"Sudplatz":                           "Südplatz",
+		"Karl Liebknechtstrasse 46":          "Karl-Liebknecht-Straße",
+		"Karl-Liebknecht-Strase 46":          "Karl-Liebknecht-Straße",
+	}
+	for q, want := range tests {
+		if p, ok := g.Lookup(q); !ok || p.Name != want {
+			t.Errorf("Lookup(%q) = %q, %v; want %q", q, p.Name, ok, want)
+		}
+	}
+	if p, ok := g.Lookup("Unbekannte Straße 1"); ok {
+		t.Errorf("Lookup found %q", p.Name)
+	}
+}
+
+func TestAddresses(t *testing.T) {
+	csv := "\ufeffStraße;Hausnummer;PLZ;Ortsteil;Breite;Länge\n" +
+		"Karl-Liebknecht-Straße;46;04275;Südvorstadt;51,32470;12,37392\n" +
+		"Karl-Liebknecht-Straße;48;04275;Südvorstadt;51,32450;12,37400\n" +
+		"Karl-Liebknecht-Straße;1;04107;Zentrum-Süd;5690000;315000\n"
+	addrs, err := ReadAddressCSV(strings.NewReader(csv))
+	if err != nil || len(addrs) != 2 {
+		t.Fatalf("ReadAddressCSV = %v, %v", addrs, err)
+	}
+	osm := `<osm><node id="1" lat="51.3172" lon="12.3837">
+		<tag k="addr:street" v="Simildenstraße"/><tag k="addr:housenumber" v="8"/>
+		<tag k="addr:suburb" v="Connewitz"/></node>
+		<node id="2" lat="51.3" lon="12.3"><tag k="amenity" v="bench"/></node></osm>`
+	more, err := ReadOSM(strings.NewReader(osm))
+	if err != nil || len(more) != 1 {
+		t.Fatalf("ReadOSM = %v, %v", more, err)
+	}
+
+	g := NewGazetteer()
+	g.AddAddresses(append(addrs, more...)...)
+	tests := map[string]Place{
+		"Karl-Liebknecht-Str. 46":    {Name: "Karl-Liebknecht-Straße 46, 04275 Leipzig", Kind: "address", Ortsteil: "Südvorstadt", Point: Point{51.3247, 12.37392}},
+		"Karl Liebknechtstr. 48-50":  {Name: "Karl-Liebknecht-Straße 48, 04275 Leipzig", Kind: "address", Ortsteil: "Südvorstadt", Point: Point{51.3245, 12.374}},
+		"Simildenstr. 8":             {Name: "Simildenstraße 8", Kind: "address", Ortsteil: "Connewitz", Point: Point{51.3172, 12.3837}},
+		"Simildenstraße 99, Leipzig": {Name: "Simildenstraße", Kind: "street", Ortsteil: "Connewitz", Point: Point{51.3172, 12.3837}},
+	}
+	for q, want := range tests {
+		if p, ok := g.Lookup(q); !ok || p != want {
+			t.Errorf("Lookup(%q) = %+v, %v; want %+v", q, p, ok, want)
+		}
+	}
+
+	var buf strings.Builder
+	if err := WriteAddresses(&buf, addrs); err != nil {
+		t.Fatal(err)
+	}
+	if again, err := ReadAddressCSV(strings.NewReader(buf.String())); err != nil || len(again) != 2 || again[0] != addrs[0] {
+		t.Errorf("round trip = %v, %v", again, err)
+	}
+}
+
+func TestMapLinks(t *testing.T) {
+	l := MapLinks(Point{51.3119, 12.3803}, "Südplatz")
+	if l.GeoURI != "geo:51.31190,12.38030" || !strings.Contains(l.OSM, "mlat=51.31190&mlon=12.38030") {
+		t.Errorf("MapLinks = %+v", l)
+	}
+	l = MapLinks(Point{}, "Südplatz")
+	if l.GeoURI != "geo:0,0?q=S%C3%BCdplatz%2C+Leipzig" || l.OSM != "https://www.openstreetmap.org/search?query=S%C3%BCdplatz%2C+Leipzig" {
+		t.Errorf("MapLinks without coordinates = %+v", l)
+	}
+	if l := MapLinks(Point{}, " "); l != (Links{}) {
+		t.Errorf("MapLinks of nothing = %+v", l)
+	}
+}
+
+func TestGeocoder(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("q") != "Simildenstraße 8, Leipzig" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`[{"lat":"51.3172","lon":"12.3837"}]`))
+	}))
+	defer srv.Close()
+
+	store, err := cache.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGeocoder(NewGazetteer(), store, srv.Client())
+	g.baseURL = srv.URL + "/search?q="
+	ctx := context.Background()
+
+	for range 2 {
+		p, err := g.Geocode(ctx, "Simildenstraße 8")
+		if err != nil || p.Point != (Point{51.3172, 12.3837}) {
+			t.Fatalf("Geocode = %v, %v", p, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("%d requests, want 1 (second lookup cached)", requests)
+	}
+	if _, err := g.Geocode(ctx, "Nirgendwo 1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown address: %v", err)
+	}
+
+	offline := NewGeocoder(NewGazetteer(), store, nil)
+	if _, ok := offline.Lookup("Simildenstr. 8"); !ok {
+		t.Error("cached address not found offline")
+	}
+	if _, err := offline.Geocode(ctx, "Nirgendwo 1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("offline: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/cache"
+)
+
+// cacheTTL is how long a geocoded address is trusted.
+const cacheTTL = 180 * 24 * time.Hour
+
+// nominatimURL searches OpenStreetMap, bounded to the Leipzig area.
+const nominatimURL = "https://nominatim.openstreetmap.org/search?format=jsonv2&limit=1&bounded=1&viewbox=12.23,51.45,12.55,51.23&q="
+
+// ErrNotFound is returned when a place can't be resolved.
+var ErrNotFound = errors.New("geo: place not found")
+
+// Geocoder resolves coordinates, gazetteer places and addresses. Results
+// from the online lookup are cached, so each address is only looked up once.
+type Geocoder struct {
+	gazetteer *Gazetteer
+	store     *cache.Store // nil disables caching
+	client    *http.Client // nil means offline
+	baseURL   string
+}
+
+// NewGeocoder returns a geocoder using g, caching in store (which may be
+// nil) and looking up unknown addresses with client (nil for offline use).
+func NewGeocoder(g *Gazetteer, store *cache.Store, client *http.Client) *Geocoder {
+	return &Geocoder{gazetteer: g, store: store, client: client, baseURL: nominatimURL}
+}
+
+// Geocode resolves query: "lat,lon", a gazetteer place, a cached address,
+// or else an online lookup.
+func (g *Geocoder) Geocode(ctx context.Context, query string) (Place, error) {
+	if p, ok := g.Lookup(query); ok {
+		return p, nil
+	}
+	if g.client == nil {
+		return Place{}, fmt.Errorf("%w: %q (offline)", ErrNotFound, query)
+	}
+
+	pt, err := g.fetch(ctx, query)
+	if err != nil {
+		return Place{}, err
+	}
+	p := Place{Name: query, Kind: "cached", Point: pt}
+	if g.store != nil {
+		_ = g.store.Put(cacheKey(query), "geocode", p)
+	}
+	return p, nil
+}
+
+// Lookup resolves query without going online.
+func (g *Geocoder) Lookup(query string) (Place, bool) {
+	if pt, ok := ParsePoint(query); ok {
+		return Place{Name: query, Kind: "place", Point: pt}, true
+	}
+	if p, ok := g.gazetteer.Lookup(query); ok {
+		return p, true
+	}
+	if g.store == nil {
+		return Place{}, false
+	}
+	e, ok, err := g.store.Get(cacheKey(query))
+	if err != nil || !ok || e.Age(time.Now()) > cacheTTL {
+		return Place{}, false
+	}
+	var p Place
+	if json.Unmarshal(e.Data, &p) != nil || p.IsZero() {
+		return Place{}, false
+	}
+	return p, true
+}
+
+func (g *Geocoder) fetch(ctx context.Context, query string) (Point, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", g.baseURL+url.QueryEscape(query+", Leipzig"), nil)
+	if err != nil {
+		return Point{}, fmt.Errorf("geo: create request: %w", err)
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return Point{}, fmt.Errorf("geo: look up %q: %w", query, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Point{}, fmt.Errorf("geo: look up %q: status %d", query, resp.StatusCode)
+	}
+
+	var results []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return Point{}, fmt.Errorf("geo: decode %q: %w", query, err)
+	}
+	if len(results) == 0 {
+		return Point{}, fmt.Errorf("%w: %q", ErrNotFound, query)
+	}
+	lat, err1 := strconv.ParseFloat(results[0].Lat, 64)
+	lon, err2 := strconv.ParseFloat(results[0].Lon, 64)
+	if err1 != nil || err2 != nil {
+		return Point{}, fmt.Errorf("geo: decode %q: bad coordinates", query)
+	}
+	return Point{lat, lon}, nil
+}
+
+func cacheKey(query string) string {
+	return "geocode:" + placeKey(query)
+}
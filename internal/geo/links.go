@@ -0,0 +1,37 @@
+package geo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Links are map links for a place: Google Maps, OpenStreetMap and a geo:
+// URI that phones open in their default map app.
+type Links struct {
+	Google string `json:"mapUrl"`
+	OSM    string `json:"osmUrl"`
+	GeoURI string `json:"geoUri"`
+}
+
+// MapLinks returns links to p, or to a search for query (with ", Leipzig"
+// appended) when p is unknown. Both empty yields no links.
+func MapLinks(p Point, query string) Links {
+	if !p.IsZero() {
+		return Links{
+			Google: fmt.Sprintf("https://maps.google.com/?q=%.5f,%.5f", p.Lat, p.Lon),
+			OSM:    fmt.Sprintf("https://www.openstreetmap.org/?mlat=%.5f&mlon=%.5f#map=17/%.5f/%.5f", p.Lat, p.Lon, p.Lat, p.Lon),
+			GeoURI: fmt.Sprintf("geo:%.5f,%.5f", p.Lat, p.Lon),
+		}
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return Links{}
+	}
+	q := query + ", Leipzig"
+	return Links{
+		Google: "https://maps.google.com/?q=" + url.QueryEscape(q),
+		OSM:    "https://www.openstreetmap.org/search?query=" + url.QueryEscape(q),
+		GeoURI: "geo:0,0?q=" + url.QueryEscape(q),
+	}
+}
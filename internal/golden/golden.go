@@ -0,0 +1,49 @@
+// Package golden compares test output against files in testdata. Run
+// tests with UPDATE_GOLDEN=1 to rewrite the files after an intended change.
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// update is set by UPDATE_GOLDEN=1. An environment variable rather than a
+// flag works with "go test ./...": packages without golden tests would
+// reject an unknown -update flag.
+var update = os.Getenv("UPDATE_GOLDEN") != ""
+
+// AssertJSON marshals got as indented JSON and compares it with the file
+// at path.
+func AssertJSON(t *testing.T, path string, got any) {
+	t.Helper()
+	data, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	Assert(t, path, append(data, '\n'))
+}
+
+// Assert compares got with the file at path.
+func Assert(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with UPDATE_GOLDEN=1 to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run with UPDATE_GOLDEN=1 if intended)\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
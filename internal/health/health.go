@@ -0,0 +1,149 @@
+// Package health fetches from a source and judges whether its output
+// looks sane, so markup changes that silently break a parser get noticed.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/httpx"
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+// Thresholds above which a share of incomplete events is an anomaly.
+const (
+	maxMissingTime     = 0.9
+	maxMissingVenue    = 0.5
+	maxMissingCategory = 0.9
+
+	// minSample is the event count below which shares aren't judged.
+	minSample = 5
+)
+
+// Report is the outcome of checking one source.
+type Report struct {
+	Source    string        `json:"source"`
+	Latency   time.Duration `json:"-"`
+	LatencyMS int64         `json:"latencyMs"`
+	Requests  int           `json:"requests"`
+	// Statuses counts HTTP responses by status code, retried ones included.
+	Statuses map[int]int `json:"statuses,omitempty"`
+	// Failures counts URLs whose last attempt failed, by status code. A
+	// 429 or 503 that a retry recovered from is not a failure.
+	Failures map[int]int `json:"failures,omitempty"`
+	Events   int         `json:"events"`
+
+	MissingTime     float64 `json:"missingTime"`
+	MissingVenue    float64 `json:"missingVenue"`
+	MissingCategory float64 `json:"missingCategory"`
+
+	Error     string   `json:"error,omitempty"`
+	Anomalies []string `json:"anomalies,omitempty"`
+}
+
+// OK reports whether the check found nothing wrong.
+func (r Report) OK() bool { return len(r.Anomalies) == 0 }
+
+// Check fetches [from, to) from src, recording every HTTP response made
+// on its behalf.
+func Check(ctx context.Context, src source.Source, from, to time.Time) Report {
+	r := Report{Source: src.ID(), Statuses: make(map[int]int)}
+
+	var mu sync.Mutex
+	last := make(map[string]int) // URL -> status of its latest attempt
+	ctx = httpx.WithTrace(ctx, &httpx.Trace{
+		Response: func(url string, status int, _ time.Duration, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			r.Requests++
+			if err == nil {
+				r.Statuses[status]++
+			}
+			last[url] = status
+		},
+	})
+
+	start := time.Now()
+	events, err := src.Fetch(ctx, from, to)
+	r.Latency = time.Since(start)
+	r.LatencyMS = r.Latency.Milliseconds()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err != nil {
+		r.Error = err.Error()
+	}
+	for _, status := range last {
+		if status >= 400 {
+			if r.Failures == nil {
+				r.Failures = make(map[int]int)
+			}
+			r.Failures[status]++
+		}
+	}
+	r.Events = len(events)
+	r.MissingTime, r.MissingVenue, r.MissingCategory = missingShares(events)
+	r.Anomalies = anomalies(r)
+	return r
+}
+
+// missingShares returns the share of events without a start time (or at
+// exactly midnight, which is how parsers record a date without a time),
+// without a venue, and without a specific category.
+func missingShares(events []model.Event) (noTime, noVenue, noCategory float64) {
+	if len(events) == 0 {
+		return 0, 0, 0
+	}
+	var t, v, c int
+	for _, e := range events {
+		if e.StartTime.IsZero() || (e.StartTime.Hour() == 0 && e.StartTime.Minute() == 0) {
+			t++
+		}
+		if e.Venue == "" {
+			v++
+		}
+		if e.Category == "" || e.Category == model.CategoryOther {
+			c++
+		}
+	}
+	n := float64(len(events))
+	return float64(t) / n, float64(v) / n, float64(c) / n
+}
+
+func anomalies(r Report) []string {
+	var out []string
+	if r.Error != "" {
+		out = append(out, "fetch failed: "+r.Error)
+	}
+	var statuses []int
+	for status := range r.Failures {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	for _, status := range statuses {
+		out = append(out, fmt.Sprintf("%d× HTTP %d", r.Failures[status], status))
+	}
+	if r.Error == "" && r.Events == 0 {
+		if r.Statuses[200] > 0 {
+			out = append(out, "0 events parsed from a 200 OK page")
+		} else {
+			out = append(out, "0 events parsed")
+		}
+	}
+	if r.Events >= minSample {
+		if r.MissingTime >= maxMissingTime {
+			out = append(out, fmt.Sprintf("%.0f%% zero-time events", r.MissingTime*100))
+		}
+		if r.MissingVenue >= maxMissingVenue {
+			out = append(out, fmt.Sprintf("%.0f%% events without venue", r.MissingVenue*100))
+		}
+		if r.MissingCategory >= maxMissingCategory {
+			out = append(out, fmt.Sprintf("%.0f%% uncategorized events", r.MissingCategory*100))
+		}
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/httpx"
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+type fakeSource struct {
+	events []model.Event
+	err    error
+}
+
+func (f fakeSource) ID() string { return "fake" }
+
+func (f fakeSource) Fetch(context.Context, time.Time, time.Time) ([]model.Event, error) {
+	return f.events, f.err
+}
+
+func TestCheck(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, loc)
+	timed := model.Event{Name: "Konzert", StartTime: day.Add(20 * time.Hour), Venue: "UT Connewitz", Category: model.CategoryConcert}
+	dateOnly := model.Event{Name: "Markt", StartTime: day, Category: model.CategoryOther}
+
+	tests := []struct {
+		name string
+		src  fakeSource
+		want []string
+	}{
+		{"healthy", fakeSource{events: []model.Event{timed, timed, timed, timed, timed}}, nil},
+		{"empty", fakeSource{}, []string{"0 events parsed"}},
+		{"error", fakeSource{err: errors.New("boom")}, []string{"fetch failed: boom"}},
+		{"drift", fakeSource{events: []model.Event{dateOnly, dateOnly, dateOnly, dateOnly, dateOnly, dateOnly, dateOnly, dateOnly, dateOnly, timed}},
+			[]string{"90% zero-time events", "90% events without venue", "90% uncategorized events"}},
+		{"small sample", fakeSource{events: []model.Event{dateOnly}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Check(context.Background(), tt.src, day, day.AddDate(0, 0, 7))
+			if !reflect.DeepEqual(r.Anomalies, tt.want) {
+				t.Errorf("anomalies = %q, want %q", r.Anomalies, tt.want)
+			}
+			if r.OK() != (tt.want == nil) {
+				t.Errorf("OK() = %v", r.OK())
+			}
+		})
+	}
+}
+
+// httpSource fetches url through the polite client and lists one event
+// per successful response.
+type httpSource struct{ url string }
+
+func (s httpSource) ID() string { return "http" }
+
+func (s httpSource) Fetch(ctx context.Context, from, _ time.Time) ([]model.Event, error) {
+	client := httpx.NewClient(httpx.Options{Interval: time.Millisecond, MaxRetries: 1, IgnoreRobots: true})
+	req, _ := http.NewRequestWithContext(ctx, "GET", s.url, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+	return []model.Event{{Name: "Konzert", StartTime: from.Add(20 * time.Hour), Venue: "UT Connewitz", Category: model.CategoryConcert}}, nil
+}
+
+func TestCheckRetriedStatus(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case calls.Add(1) == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	r := Check(context.Background(), httpSource{srv.URL + "/throttled"}, day, day.AddDate(0, 0, 1))
+	if !r.OK() || r.Statuses[429] != 1 || r.Statuses[200] != 1 {
+		t.Errorf("throttled once: anomalies %q, statuses %v", r.Anomalies, r.Statuses)
+	}
+
+	r = Check(context.Background(), httpSource{srv.URL + "/down"}, day, day.AddDate(0, 0, 1))
+	if want := []string{"1× HTTP 503", "0 events parsed"}; !reflect.DeepEqual(r.Anomalies, want) {
+		t.Errorf("down: anomalies = %q, want %q", r.Anomalies, want)
+	}
+}
@@ -0,0 +1,112 @@
+package httpx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// validatorStore keeps the last 200 response of each URL that carried an
+// ETag or Last-Modified, so the next request can be made conditional and
+// a 304 answered from disk.
+type validatorStore struct {
+	dir string
+}
+
+type storedResponse struct {
+	URL          string      `json:"url"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+func (s *validatorStore) path(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])[:16]+".json")
+}
+
+func (s *validatorStore) load(rawURL string) *storedResponse {
+	data, err := os.ReadFile(s.path(rawURL))
+	if err != nil {
+		return nil
+	}
+	var sr storedResponse
+	if json.Unmarshal(data, &sr) != nil || sr.URL != rawURL {
+		return nil
+	}
+	return &sr
+}
+
+// save stores body if the response carries validators. Failures are
+// ignored; the next request is simply unconditional.
+func (s *validatorStore) save(rawURL string, resp *http.Response, body []byte) {
+	sr := storedResponse{
+		URL:          rawURL,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Header:       http.Header{},
+		Body:         body,
+	}
+	if sr.ETag == "" && sr.LastModified == "" {
+		return
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		sr.Header.Set("Content-Type", ct)
+	}
+	data, err := json.Marshal(sr)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.Write(data)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil || os.Rename(tmp.Name(), s.path(rawURL)) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// addConditions sets If-None-Match / If-Modified-Since on req.
+func (sr *storedResponse) addConditions(req *http.Request) {
+	if sr == nil {
+		return
+	}
+	if sr.ETag != "" && req.Header.Get("If-None-Match") == "" {
+		req.Header.Set("If-None-Match", sr.ETag)
+	}
+	if sr.LastModified != "" && req.Header.Get("If-Modified-Since") == "" {
+		req.Header.Set("If-Modified-Since", sr.LastModified)
+	}
+}
+
+// response rebuilds a 200 response from the stored body.
+func (sr *storedResponse) response(req *http.Request) *http.Response {
+	header := sr.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(sr.Body)))
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(sr.Body)),
+		ContentLength: int64(len(sr.Body)),
+		Request:       req,
+	}
+}
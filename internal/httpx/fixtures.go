@@ -0,0 +1,159 @@
+package httpx
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fixtures is a RoundTripper that records responses to a directory or
+// replays them from it, so scrapers can be tested offline against real
+// pages. Each response is stored as a raw HTTP dump named after the
+// request URL.
+type Fixtures struct {
+	dir    string
+	record bool
+	base   http.RoundTripper
+}
+
+// Record returns fixtures that send requests through base and store the
+// responses in dir.
+func Record(dir string, base http.RoundTripper) *Fixtures {
+	if base == nil {
+		base = NewTransport(Options{})
+	}
+	return &Fixtures{dir: dir, record: true, base: base}
+}
+
+// Replay returns fixtures that answer requests from dir and fail for any
+// request that wasn't recorded.
+func Replay(dir string) *Fixtures {
+	return &Fixtures{dir: dir}
+}
+
+// Client returns an http.Client using f.
+func (f *Fixtures) Client() *http.Client {
+	return &http.Client{Transport: f}
+}
+
+func (f *Fixtures) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := filepath.Join(f.dir, FixtureName(req.Method, req.URL.String()))
+	if f.record {
+		return f.save(path, req)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("httpx: no fixture for %s %s (%s)", req.Method, req.URL, filepath.Base(path))
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	if err != nil {
+		return nil, fmt.Errorf("httpx: read fixture %s: %w", path, err)
+	}
+	return resp, nil
+}
+
+func (f *Fixtures) save(path string, req *http.Request) (*http.Response, error) {
+	resp, err := f.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// Store a self-contained, cookie-free response.
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	header.Del("Transfer-Encoding")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	header.Set("X-Fixture-Url", req.URL.String())
+	dump := *resp
+	dump.Header = header
+	dump.TransferEncoding = nil
+	dump.ContentLength = int64(len(body))
+	dump.Body = io.NopCloser(bytes.NewReader(body))
+
+	data, err := httputil.DumpResponse(&dump, true)
+	if err != nil {
+		return nil, fmt.Errorf("httpx: dump response: %w", err)
+	}
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("httpx: create %s: %w", f.dir, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("httpx: write fixture: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// FixtureName returns the file name a request is stored under: a readable
+// slug of host and path plus a hash of the full URL.
+func FixtureName(method, rawURL string) string {
+	sum := sha256.Sum256([]byte(method + " " + rawURL))
+	slug := rawURL
+	if i := strings.Index(slug, "://"); i >= 0 {
+		slug = slug[i+3:]
+	}
+	if i := strings.IndexAny(slug, "?#"); i >= 0 {
+		slug = slug[:i]
+	}
+	slug = strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(slug), "-"), "-")
+	if len(slug) > 60 {
+		slug = strings.Trim(slug[len(slug)-60:], "-")
+	}
+	return slug + "-" + hex.EncodeToString(sum[:])[:8] + ".http"
+}
+
+// Recording describes when fixtures were recorded and for which range, so
+// tests can repeat the exact requests.
+type Recording struct {
+	RecordedAt time.Time `json:"recordedAt"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+}
+
+const recordingFile = "recording.json"
+
+// SaveRecording writes r to dir.
+func SaveRecording(dir string, r Recording) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("httpx: create %s: %w", dir, err)
+	}
+	return os.WriteFile(filepath.Join(dir, recordingFile), append(data, '\n'), 0o644)
+}
+
+// LoadRecording reads the Recording in dir.
+func LoadRecording(dir string) (Recording, error) {
+	var r Recording
+	data, err := os.ReadFile(filepath.Join(dir, recordingFile))
+	if err != nil {
+		return r, fmt.Errorf("httpx: %w", err)
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return r, fmt.Errorf("httpx: parse %s: %w", recordingFile, err)
+	}
+	return r, nil
+}
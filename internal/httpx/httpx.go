@@ -0,0 +1,267 @@
+// Package httpx is the HTTP client shared by all scrapers. Its transport
+// rate-limits per host (shared across concurrent fetches), retries 429/5xx
+// responses with jittered backoff honouring Retry-After, respects
+// robots.txt, revalidates with ETag/Last-Modified, and caps response sizes.
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// UserAgent is sent with every request that doesn't set its own.
+const UserAgent = "leipzig-cli/1.0 (+https://github.com/havocked/leipzig-cli)"
+
+var (
+	// ErrDisallowed is returned for URLs robots.txt doesn't allow us to fetch.
+	ErrDisallowed = errors.New("disallowed by robots.txt")
+	// ErrTooLarge is returned when a response exceeds Options.MaxBodySize.
+	ErrTooLarge = errors.New("response body too large")
+)
+
+// Options configures a client. Zero values select the defaults.
+type Options struct {
+	UserAgent string
+	// Timeout bounds a whole request including retries (default 30s). A
+	// retry whose wait would not fit is skipped and the last response or
+	// error returned.
+	Timeout time.Duration
+	// Interval is the minimum spacing between requests to one host
+	// (default 500ms). Burst allows that many requests back to back.
+	Interval time.Duration
+	Burst    int
+	// MaxRetries is how often 429/5xx and network errors are retried (default 3).
+	MaxRetries int
+	// MaxBodySize caps response bodies (default 10 MiB).
+	MaxBodySize int64
+	// IgnoreRobots disables robots.txt checks.
+	IgnoreRobots bool
+	// CacheDir stores validators and bodies for conditional requests.
+	// Empty disables conditional requests.
+	CacheDir string
+	// Base is the underlying transport (default http.DefaultTransport).
+	Base http.RoundTripper
+}
+
+func (o Options) withDefaults() Options {
+	if o.UserAgent == "" {
+		o.UserAgent = UserAgent
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 30 * time.Second
+	}
+	if o.Interval <= 0 {
+		o.Interval = 500 * time.Millisecond
+	}
+	if o.Burst <= 0 {
+		o.Burst = 1
+	}
+	if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	} else if o.MaxRetries == 0 {
+		o.MaxRetries = 3
+	}
+	if o.MaxBodySize <= 0 {
+		o.MaxBodySize = 10 << 20
+	}
+	if o.Base == nil {
+		o.Base = http.DefaultTransport
+	}
+	return o
+}
+
+// DefaultOptions returns the options used by Default, with conditional
+// requests stored under the user cache directory.
+func DefaultOptions() Options {
+	var opts Options
+	if dir, err := os.UserCacheDir(); err == nil {
+		opts.CacheDir = filepath.Join(dir, "leipzig", "http")
+	}
+	return opts
+}
+
+var (
+	defaultMu     sync.Mutex
+	defaultClient *http.Client
+)
+
+// Default returns the process-wide client.
+func Default() *http.Client {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultClient == nil {
+		defaultClient = NewClient(DefaultOptions())
+	}
+	return defaultClient
+}
+
+// SetDefault replaces the process-wide client returned by Default.
+func SetDefault(c *http.Client) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultClient = c
+}
+
+// NewClient returns a client using a new Transport.
+func NewClient(opts Options) *http.Client {
+	t := NewTransport(opts)
+	return &http.Client{Transport: t, Timeout: t.opts.Timeout}
+}
+
+// Transport is a polite http.RoundTripper. See the package comment.
+type Transport struct {
+	opts       Options
+	limiter    *limiter
+	robots     *robotsCache
+	validators *validatorStore
+}
+
+// NewTransport returns a transport. All transports share per-host rate
+// limits, so concurrent scrapers of one site don't add up.
+func NewTransport(opts Options) *Transport {
+	opts = opts.withDefaults()
+	t := &Transport{
+		opts:    opts,
+		limiter: sharedLimiter,
+	}
+	if !opts.IgnoreRobots {
+		t.robots = newRobotsCache(opts.Base, opts.UserAgent)
+	}
+	if opts.CacheDir != "" {
+		t.validators = &validatorStore{dir: opts.CacheDir}
+	}
+	return t
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	req = req.Clone(ctx)
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", t.opts.UserAgent)
+	}
+
+	if t.robots != nil {
+		rules := t.robots.get(ctx, req.URL)
+		if !rules.allowed(req.URL) {
+			return nil, fmt.Errorf("%s: %w", req.URL, ErrDisallowed)
+		}
+		t.limiter.setMinInterval(req.URL.Host, rules.crawlDelay)
+	}
+
+	var stored *storedResponse
+	if t.validators != nil && req.Method == http.MethodGet {
+		stored = t.validators.load(req.URL.String())
+		stored.addConditions(req)
+	}
+
+	resp, err := t.doWithRetry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && stored != nil {
+		resp.Body.Close()
+		return stored.response(req), nil
+	}
+
+	body, err := readLimited(resp.Body, t.opts.MaxBodySize)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", req.URL, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+
+	if t.validators != nil && req.Method == http.MethodGet && resp.StatusCode == http.StatusOK {
+		t.validators.save(req.URL.String(), resp, body)
+	}
+	return resp, nil
+}
+
+// doWithRetry sends req, waiting for the host's rate limit before each
+// attempt and retrying retryable failures.
+func (t *Transport) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.wait(ctx, req.URL.Host, t.opts.Interval, t.opts.Burst); err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		resp, err := t.opts.Base.RoundTrip(req)
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		traceResponse(ctx, req.URL.String(), status, time.Since(start), err)
+
+		last := !idempotent || attempt >= t.opts.MaxRetries
+		switch {
+		case err != nil:
+			if last || !retryableError(ctx, err) {
+				return nil, err
+			}
+			delay := backoff(attempt)
+			if !fits(ctx, delay) {
+				return nil, err
+			}
+			if werr := sleep(ctx, delay); werr != nil {
+				return nil, werr
+			}
+		case retryableStatus(resp.StatusCode) && !last:
+			delay := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+			if delay <= 0 {
+				delay = backoff(attempt)
+			}
+			if !fits(ctx, delay) {
+				// Waiting would run into the timeout; a 429 or 503 says
+				// more than a deadline error.
+				return resp, nil
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+			if werr := sleep(ctx, delay); werr != nil {
+				return nil, werr
+			}
+		default:
+			return resp, nil
+		}
+	}
+}
+
+// fits reports whether waiting d still leaves time for another attempt
+// before ctx's deadline (the client timeout, if any).
+func fits(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > d+time.Second
+}
+
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w (over %d bytes)", ErrTooLarge, limit)
+	}
+	return body, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
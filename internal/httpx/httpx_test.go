@@ -0,0 +1,239 @@
+package httpx
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testClient(t *testing.T, opts Options) *http.Client {
+	t.Helper()
+	opts.Interval = time.Millisecond
+	return NewClient(opts)
+}
+
+func get(t *testing.T, c *http.Client, u string) (*http.Response, string, error) {
+	t.Helper()
+	resp, err := c.Get(u)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body), nil
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	resp, body, err := get(t, testClient(t, Options{}), srv.URL+"/page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 200 || body != "ok" || calls.Load() != 2 {
+		t.Errorf("got %d %q after %d calls, want 200 \"ok\" after 2", resp.StatusCode, body, calls.Load())
+	}
+}
+
+func TestRetriesExhausted(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	resp, _, err := get(t, testClient(t, Options{MaxRetries: 2, IgnoreRobots: true}), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 503 || calls.Load() != 3 {
+		t.Errorf("got %d after %d calls, want 503 after 3", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestRetryAfterBeyondTimeout(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	start := time.Now()
+	resp, _, err := get(t, testClient(t, Options{Timeout: 5 * time.Second, IgnoreRobots: true}), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 429 || calls.Load() != 1 || time.Since(start) > time.Second {
+		t.Errorf("got %d after %d calls in %v, want 429 at once", resp.StatusCode, calls.Load(), time.Since(start))
+	}
+}
+
+func TestRobotsDisallow(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			io.WriteString(w, "User-agent: *\nDisallow: /private\n")
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	c := testClient(t, Options{})
+	if _, _, err := get(t, c, srv.URL+"/private/page"); !errors.Is(err, ErrDisallowed) {
+		t.Errorf("private: err = %v, want ErrDisallowed", err)
+	}
+	if _, body, err := get(t, c, srv.URL+"/public"); err != nil || body != "ok" {
+		t.Errorf("public: %q, %v", body, err)
+	}
+}
+
+func TestConditionalRequest(t *testing.T) {
+	var notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		io.WriteString(w, "body v1")
+	}))
+	defer srv.Close()
+
+	c := testClient(t, Options{IgnoreRobots: true, CacheDir: t.TempDir()})
+	for i := 0; i < 2; i++ {
+		resp, body, err := get(t, c, srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != 200 || body != "body v1" {
+			t.Errorf("request %d: got %d %q", i+1, resp.StatusCode, body)
+		}
+	}
+	if notModified.Load() != 1 {
+		t.Errorf("server answered 304 %d times, want 1", notModified.Load())
+	}
+}
+
+func TestSizeLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, strings.Repeat("x", 100))
+	}))
+	defer srv.Close()
+
+	_, _, err := get(t, testClient(t, Options{IgnoreRobots: true, MaxBodySize: 50}), srv.URL)
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("err = %v, want ErrTooLarge", err)
+	}
+}
+
+func TestParseRobots(t *testing.T) {
+	const robots = `
+User-agent: *
+Disallow: /
+
+User-agent: leipzig-cli
+Disallow: /suche
+Allow: /suche/ergebnisse$
+Crawl-delay: 2
+`
+	rules := parseRobots(strings.NewReader(robots), UserAgent)
+	if rules.crawlDelay != 2*time.Second {
+		t.Errorf("crawlDelay = %v, want 2s", rules.crawlDelay)
+	}
+	for path, want := range map[string]bool{
+		"/veranstaltungen":    true,
+		"/suche?q=x":          false,
+		"/suche/ergebnisse":   true,
+		"/suche/ergebnisse/2": false,
+	} {
+		u, _ := url.Parse("https://example.org" + path)
+		if got := rules.allowed(u); got != want {
+			t.Errorf("allowed(%s) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestParseRobotsAgents(t *testing.T) {
+	tests := map[string]bool{
+		// Parts of our product token aren't our group.
+		"User-agent: l\nDisallow: /":   true,
+		"User-agent: cli\nDisallow: /": true,
+		"User-agent:\nDisallow: /":     true,
+		// Case and version don't matter.
+		"User-agent: Leipzig-CLI\nDisallow: /":     false,
+		"User-agent: leipzig-cli/2.0\nDisallow: /": false,
+		"User-agent: leipzig-cli-bot\nDisallow: /": true,
+	}
+	u, _ := url.Parse("https://example.org/veranstaltungen")
+	for robots, want := range tests {
+		rules := parseRobots(strings.NewReader("User-agent: *\nAllow: /\n\n"+robots), UserAgent)
+		if got := rules.allowed(u); got != want {
+			t.Errorf("%q: allowed = %v, want %v", robots, got, want)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	for in, want := range map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"junk":                          0,
+		"Sat, 17 Oct 2026 12:00:30 GMT": 30 * time.Second,
+		"3600":                          maxRetryAfter,
+	} {
+		if got := retryAfter(in, now); got != want {
+			t.Errorf("retryAfter(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		io.WriteString(w, "page "+r.URL.Query().Get("p"))
+	}))
+	dir := t.TempDir()
+
+	rec := Record(dir, http.DefaultTransport).Client()
+	if _, body, err := get(t, rec, srv.URL+"?p=1"); err != nil || body != "page 1" {
+		t.Fatalf("record: %q, %v", body, err)
+	}
+	srv.Close()
+
+	replay := Replay(dir).Client()
+	resp, body, err := get(t, replay, srv.URL+"?p=1")
+	if err != nil || body != "page 1" {
+		t.Fatalf("replay: %q, %v", body, err)
+	}
+	if resp.Header.Get("Set-Cookie") != "" {
+		t.Error("fixture kept Set-Cookie")
+	}
+	if _, _, err := get(t, replay, srv.URL+"?p=2"); err == nil {
+		t.Error("expected an error for an unrecorded request")
+	}
+}
@@ -0,0 +1,84 @@
+package httpx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sharedLimiter holds the token buckets of every Transport.
+var sharedLimiter = &limiter{buckets: make(map[string]*bucket)}
+
+// limiter is a set of per-host token buckets.
+type limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket is a token bucket refilling one token per interval.
+type bucket struct {
+	interval    time.Duration
+	minInterval time.Duration // from robots.txt Crawl-delay
+	burst       int
+	tokens      float64
+	last        time.Time
+}
+
+// wait blocks until a request to host may be sent.
+func (l *limiter) wait(ctx context.Context, host string, interval time.Duration, burst int) error {
+	for {
+		delay := l.reserve(host, interval, burst)
+		if delay <= 0 {
+			return nil
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns 0, or returns how
+// long to wait before trying again.
+func (l *limiter) reserve(host string, interval time.Duration, burst int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &bucket{tokens: float64(burst), last: now}
+		l.buckets[host] = b
+	}
+	b.interval = interval
+	if b.minInterval > b.interval {
+		b.interval = b.minInterval
+	}
+	b.burst = burst
+
+	b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) * float64(b.interval))
+}
+
+// setMinInterval raises host's spacing to at least d (robots.txt Crawl-delay).
+func (l *limiter) setMinInterval(host string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &bucket{tokens: 1, last: time.Now()}
+		l.buckets[host] = b
+	}
+	b.minInterval = d
+}
@@ -0,0 +1,210 @@
+package httpx
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// robotsCache fetches and remembers each host's robots.txt for the
+// lifetime of the process.
+type robotsCache struct {
+	base      http.RoundTripper
+	userAgent string
+
+	mu    sync.Mutex
+	hosts map[string]*robotsEntry
+}
+
+type robotsEntry struct {
+	once  sync.Once
+	rules robotsRules
+}
+
+// robotsRules are the rules of the group that applies to us.
+type robotsRules struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+type robotsRule struct {
+	allow bool
+	path  string
+}
+
+func newRobotsCache(base http.RoundTripper, userAgent string) *robotsCache {
+	return &robotsCache{base: base, userAgent: userAgent, hosts: make(map[string]*robotsEntry)}
+}
+
+func (c *robotsCache) get(ctx context.Context, u *url.URL) robotsRules {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return robotsRules{}
+	}
+	key := u.Scheme + "://" + u.Host
+	c.mu.Lock()
+	e, ok := c.hosts[key]
+	if !ok {
+		e = &robotsEntry{}
+		c.hosts[key] = e
+	}
+	c.mu.Unlock()
+
+	e.once.Do(func() { e.rules = c.fetch(ctx, key) })
+	return e.rules
+}
+
+// fetch loads robots.txt. Anything but a 200 (missing file, server error,
+// network failure) is treated as "everything allowed".
+func (c *robotsCache) fetch(ctx context.Context, origin string) robotsRules {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return robotsRules{}
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	resp, err := c.base.RoundTrip(req)
+	if err != nil {
+		return robotsRules{}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return robotsRules{}
+	}
+	return parseRobots(io.LimitReader(resp.Body, 512<<10), c.userAgent)
+}
+
+// productToken returns the lowercased name part of a user agent:
+// "leipzig-cli" for "Leipzig-CLI/1.0 (+https://...)".
+func productToken(userAgent string) string {
+	token := strings.ToLower(strings.TrimSpace(userAgent))
+	if i := strings.IndexAny(token, "/ "); i >= 0 {
+		token = token[:i]
+	}
+	return token
+}
+
+// parseRobots returns the rules of the group whose user agent is our
+// product token, falling back to the "*" group.
+func parseRobots(r io.Reader, userAgent string) robotsRules {
+	token := productToken(userAgent)
+
+	var (
+		specific, wildcard robotsRules
+		haveSpecific       bool
+		agents             []string
+		inRules            bool
+	)
+	apply := func(fn func(*robotsRules)) {
+		for _, a := range agents {
+			switch {
+			case a == "*":
+				fn(&wildcard)
+			case a == token:
+				haveSpecific = true
+				fn(&specific)
+			}
+		}
+	}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if inRules {
+				agents = nil
+				inRules = false
+			}
+			if a := productToken(value); a != "" {
+				agents = append(agents, a)
+			}
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue // "Disallow:" allows everything
+			}
+			rule := robotsRule{allow: key == "allow", path: value}
+			apply(func(rr *robotsRules) { rr.rules = append(rr.rules, rule) })
+		case "crawl-delay":
+			inRules = true
+			secs, err := strconv.ParseFloat(value, 64)
+			if err != nil || secs <= 0 {
+				continue
+			}
+			d := time.Duration(secs * float64(time.Second))
+			if d > time.Minute {
+				d = time.Minute
+			}
+			apply(func(rr *robotsRules) { rr.crawlDelay = d })
+		}
+	}
+	if haveSpecific {
+		return specific
+	}
+	return wildcard
+}
+
+// allowed applies the longest matching rule; Allow wins ties.
+func (r robotsRules) allowed(u *url.URL) bool {
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+
+	best, allow := -1, true
+	for _, rule := range r.rules {
+		if !robotsMatch(rule.path, path) {
+			continue
+		}
+		n := len(rule.path)
+		if n > best || (n == best && rule.allow) {
+			best, allow = n, rule.allow
+		}
+	}
+	return allow
+}
+
+// robotsMatch matches a robots.txt path pattern with "*" wildcards and an
+// optional "$" end anchor.
+func robotsMatch(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 && anchored {
+			return strings.HasSuffix(rest, part)
+		}
+		j := strings.Index(rest, part)
+		if j < 0 {
+			return false
+		}
+		rest = rest[j+len(part):]
+	}
+	return !anchored || rest == ""
+}
@@ -0,0 +1,25 @@
+package httpx
+
+import (
+	"context"
+	"time"
+)
+
+// Trace is told about every attempt a Transport makes for requests whose
+// context carries it, including retries. Err is set for transport errors.
+type Trace struct {
+	Response func(url string, status int, elapsed time.Duration, err error)
+}
+
+type traceKey struct{}
+
+// WithTrace returns a context whose requests report to t.
+func WithTrace(ctx context.Context, t *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, t)
+}
+
+func traceResponse(ctx context.Context, url string, status int, elapsed time.Duration, err error) {
+	if t, _ := ctx.Value(traceKey{}).(*Trace); t != nil && t.Response != nil {
+		t.Response(url, status, elapsed, err)
+	}
+}
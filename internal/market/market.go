@@ -13,29 +13,38 @@ type Schedule struct {
 }
 
 type Market struct {
-	Name     string     `json:"name"`
-	Private  bool       `json:"private,omitempty"`
+	Name    string `json:"name"`
+	Private bool   `json:"private,omitempty"`
+	// Ortsteil is the market's district (see package district).
+	Ortsteil  string     `json:"ortsteil"`
 	Schedules []Schedule `json:"-"`
-	Notes    string     `json:"notes,omitempty"`
-	MapURL   string     `json:"map_url"`
+	Notes     string     `json:"notes,omitempty"`
+	MapURL    string     `json:"map_url"`
 }
 
 type MarketDay struct {
-	Name    string `json:"name"`
-	Open    string `json:"open"`
-	Close   string `json:"close"`
-	Notes   string `json:"notes,omitempty"`
-	Private bool   `json:"private,omitempty"`
-	MapURL  string `json:"map_url"`
+	Name     string `json:"name"`
+	Ortsteil string `json:"ortsteil"`
+	Open     string `json:"open"`
+	Close    string `json:"close"`
+	Notes    string `json:"notes,omitempty"`
+	Private  bool   `json:"private,omitempty"`
+	MapURL   string `json:"map_url"`
+	// Lat, Lon, OSMURL and GeoURI are set when the location is resolved.
+	Lat    float64 `json:"lat,omitempty"`
+	Lon    float64 `json:"lon,omitempty"`
+	OSMURL string  `json:"osm_url,omitempty"`
+	GeoURI string  `json:"geo_uri,omitempty"`
 }
 
 func mapURL(location string) string {
 	return "https://maps.google.com/?q=" + url.QueryEscape(location+", Leipzig")
 }
 
-func m(name string, private bool, schedules []Schedule, notes string) Market {
+func m(name, ortsteil string, private bool, schedules []Schedule, notes string) Market {
 	return Market{
 		Name:      name,
+		Ortsteil:  ortsteil,
 		Private:   private,
 		Schedules: schedules,
 		Notes:     notes,
@@ -44,58 +53,58 @@ func m(name string, private bool, schedules []Schedule, notes string) Market {
 }
 
 var Markets = []Market{
-	m("Innenstadt (Marktplatz)", false, []Schedule{
+	m("Innenstadt (Marktplatz)", "Zentrum", false, []Schedule{
 		{time.Tuesday, "09:00", "17:00"},
 		{time.Friday, "09:00", "17:00"},
 	}, ""),
-	m("Bayrischer Platz", false, []Schedule{
+	m("Bayrischer Platz", "Zentrum-Südost", false, []Schedule{
 		{time.Wednesday, "09:00", "17:00"},
 		{time.Friday, "09:00", "17:00"},
 	}, ""),
-	m("Lindenauer Markt", false, []Schedule{
+	m("Lindenauer Markt", "Lindenau", false, []Schedule{
 		{time.Wednesday, "09:00", "16:00"},
 		{time.Friday, "09:00", "16:00"},
 	}, ""),
-	m("Gohlis-Park", false, []Schedule{
+	m("Gohlis-Park", "Gohlis-Mitte", false, []Schedule{
 		{time.Tuesday, "09:00", "16:00"},
 		{time.Thursday, "09:00", "15:00"},
 	}, "Thu until 15:00"),
-	m("Gohlis-Arkaden", false, []Schedule{
+	m("Gohlis-Arkaden", "Gohlis-Süd", false, []Schedule{
 		{time.Wednesday, "09:00", "15:00"},
 	}, ""),
-	m("Lößnig", false, []Schedule{
+	m("Lößnig", "Lößnig", false, []Schedule{
 		{time.Thursday, "09:00", "14:00"},
 		{time.Saturday, "08:30", "12:00"},
 	}, "Sat 08:30-12:00"),
-	m("Grünau WK 4", false, []Schedule{
+	m("Grünau WK 4", "Grünau-Mitte", false, []Schedule{
 		{time.Tuesday, "09:00", "14:00"},
 		{time.Thursday, "09:00", "14:00"},
 	}, ""),
-	m("Grünau WK 2", false, []Schedule{
+	m("Grünau WK 2", "Grünau-Ost", false, []Schedule{
 		{time.Friday, "09:00", "12:00"},
 	}, ""),
-	m("Grünau WK 7", false, []Schedule{
+	m("Grünau WK 7", "Lausen-Grünau", false, []Schedule{
 		{time.Wednesday, "09:00", "12:00"},
 	}, ""),
-	m("Paunsdorf", false, []Schedule{
+	m("Paunsdorf", "Paunsdorf", false, []Schedule{
 		{time.Thursday, "08:30", "13:30"},
 	}, ""),
-	m("Torgauer Platz", false, []Schedule{
+	m("Torgauer Platz", "Neustadt-Neuschönefeld", false, []Schedule{
 		{time.Thursday, "09:00", "14:00"},
 	}, ""),
-	m("Richard-Wagner-Platz", false, []Schedule{
+	m("Richard-Wagner-Platz", "Zentrum", false, []Schedule{
 		{time.Saturday, "10:00", "16:00"},
 	}, ""),
-	m("Liebertwolkwitz", false, []Schedule{
+	m("Liebertwolkwitz", "Liebertwolkwitz", false, []Schedule{
 		{time.Friday, "08:00", "13:00"},
 	}, ""),
-	m("Wiederitzsch", false, []Schedule{
+	m("Wiederitzsch", "Wiederitzsch", false, []Schedule{
 		{time.Thursday, "09:00", "12:00"},
 	}, ""),
-	m("Sportforum", true, []Schedule{
+	m("Sportforum", "Zentrum-Nordwest", true, []Schedule{
 		{time.Saturday, "09:00", "16:00"},
 	}, ""),
-	m("Plagwitzer Markthalle", true, []Schedule{
+	m("Plagwitzer Markthalle", "Plagwitz", true, []Schedule{
 		{time.Saturday, "09:00", "14:00"},
 	}, ""),
 }
@@ -111,12 +120,13 @@ func ForDay(day time.Weekday) []MarketDay {
 					name += " (private)"
 				}
 				result = append(result, MarketDay{
-					Name:    name,
-					Open:    s.Open,
-					Close:   s.Close,
-					Notes:   mk.Notes,
-					Private: mk.Private,
-					MapURL:  mk.MapURL,
+					Name:     name,
+					Open:     s.Open,
+					Close:    s.Close,
+					Notes:    mk.Notes,
+					Private:  mk.Private,
+					Ortsteil: mk.Ortsteil,
+					MapURL:   mk.MapURL,
 				})
 			}
 		}
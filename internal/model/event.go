@@ -2,7 +2,6 @@ package model
 
 import (
 	"fmt"
-	"net/url"
 	"time"
 )
 
@@ -30,32 +29,97 @@ var CategoryEmoji = map[string]string{
 	CategoryOther:      "📌",
 }
 
+// ValidCategory reports whether c is one of the canonical categories.
+func ValidCategory(c string) bool {
+	_, ok := CategoryEmoji[c]
+	return ok
+}
+
 type Event struct {
+	// ID is set by the engine; see EventID.
+	ID          string    `json:"id,omitempty"`
 	Name        string    `json:"name"`
 	Description string    `json:"description,omitempty"`
 	StartTime   time.Time `json:"startTime"`
 	EndTime     time.Time `json:"endTime,omitzero"`
 	Venue       string    `json:"venue,omitempty"`
 	Address     string    `json:"address,omitempty"`
-	Category    string    `json:"category"`
-	Tags        []string  `json:"tags,omitempty"`
-	Price       string    `json:"price,omitempty"`
-	URL         string    `json:"url,omitempty"`
-	ImageURL    string    `json:"imageUrl,omitempty"`
-	MapURL      string    `json:"mapUrl,omitempty"`
-	Source      string    `json:"source"`
+	// VenueID is set when the venue is in the venue registry; Lat and Lon
+	// come from the registry or from the address.
+	VenueID string  `json:"venueId,omitempty"`
+	Lat     float64 `json:"lat,omitempty"`
+	Lon     float64 `json:"lon,omitempty"`
+	// District is the Ortsteil, from the venue registry or the address.
+	District string `json:"district,omitempty"`
+	// DistanceMeters is set when filtering by distance (--near).
+	DistanceMeters *int     `json:"distanceMeters,omitempty"`
+	Category       string   `json:"category"`
+	Tags           []string `json:"tags,omitempty"`
+	Price          string   `json:"price,omitempty"`
+	// Pricing is Price parsed; the engine sets it.
+	Pricing   *Pricing `json:"pricing,omitempty"`
+	Organizer string   `json:"organizer,omitempty"`
+	URL       string   `json:"url,omitempty"`
+	ImageURL  string   `json:"imageUrl,omitempty"`
+	MapURL    string   `json:"mapUrl,omitempty"`
+	OSMURL    string   `json:"osmUrl,omitempty"`
+	GeoURI    string   `json:"geoUri,omitempty"`
+	Source    string   `json:"source"`
+	// Sources lists every source the event was found in, Source first.
+	Sources []SourceRef `json:"sources,omitempty"`
+	// FieldSources records, for merged duplicates, which source supplied
+	// each field (keyed by JSON name).
+	FieldSources map[string]string `json:"fieldSources,omitempty"`
+}
+
+// End returns when e ends: EndTime, or StartTime if that is unknown. A
+// date-only event (both times at midnight, as sources write "01.02. –
+// 30.04.") runs to the end of its last day.
+func (e Event) End() time.Time {
+	end := e.EndTime
+	if end.IsZero() {
+		end = e.StartTime
+	}
+	if dateOnly(e.StartTime) && dateOnly(end) {
+		end = time.Date(end.Year(), end.Month(), end.Day()+1, 0, 0, 0, 0, end.Location())
+	}
+	return end
+}
+
+// Overlaps reports whether e takes place at any time in [from, to); a zero
+// bound is open. An event starting exactly at to belongs to the next range,
+// and one ending exactly at from is over by then.
+func (e Event) Overlaps(from, to time.Time) bool {
+	if !to.IsZero() && !e.StartTime.Before(to) {
+		return false
+	}
+	if from.IsZero() {
+		return true
+	}
+	end := e.End()
+	return end.After(from) || end.Equal(from) && end.Equal(e.StartTime)
+}
+
+// MultiDay reports whether e runs longer than a day, like an exhibition or
+// a festival.
+func (e Event) MultiDay() bool {
+	return e.End().Sub(e.StartTime) > 24*time.Hour
+}
+
+func dateOnly(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0
 }
 
-// MapsURL returns a Google Maps search URL for the venue.
-func (e Event) MapsURL() string {
+// Location is what a map search for the event looks for: its address,
+// else its venue.
+func (e Event) Location() string {
 	if e.Venue == "" {
 		return ""
 	}
-	q := e.Venue + ", Leipzig"
 	if e.Address != "" {
-		q = e.Address + ", Leipzig"
+		return e.Address
 	}
-	return "https://maps.google.com/?q=" + url.QueryEscape(q)
+	return e.Venue
 }
 
 func (e Event) String() string {
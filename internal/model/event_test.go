@@ -0,0 +1,57 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventEnd(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	day := func(m time.Month, d, h int) time.Time { return time.Date(2026, m, d, h, 0, 0, 0, loc) }
+	exhibition := Event{StartTime: day(2, 1, 0), EndTime: day(4, 30, 0)}
+	concert := Event{StartTime: day(3, 7, 20)}
+	market := Event{StartTime: day(3, 7, 0)}
+
+	tests := []struct {
+		e        Event
+		end      time.Time
+		multiDay bool
+	}{
+		{exhibition, day(5, 1, 0), true},
+		{concert, day(3, 7, 20), false},
+		{market, day(3, 8, 0), false},
+		{Event{StartTime: day(3, 7, 22), EndTime: day(3, 8, 0)}, day(3, 8, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.e.End(); !got.Equal(tt.end) {
+			t.Errorf("End(%v – %v) = %v, want %v", tt.e.StartTime, tt.e.EndTime, got, tt.end)
+		}
+		if got := tt.e.MultiDay(); got != tt.multiDay {
+			t.Errorf("MultiDay(%v – %v) = %v", tt.e.StartTime, tt.e.EndTime, got)
+		}
+	}
+
+	// The weekend of 28/29 March, across the switch to summer time.
+	sat, mon := day(3, 28, 0), day(3, 30, 0)
+	if !exhibition.Overlaps(sat, mon) {
+		t.Error("exhibition running through the weekend doesn't overlap it")
+	}
+	if exhibition.Overlaps(day(5, 1, 10), time.Time{}) {
+		t.Error("exhibition overlaps the day after it closed")
+	}
+	if !exhibition.Overlaps(day(4, 30, 18), time.Time{}) {
+		t.Error("exhibition doesn't overlap the evening of its last day")
+	}
+	if concert.Overlaps(sat, mon) || !concert.Overlaps(time.Time{}, time.Time{}) {
+		t.Error("concert overlaps wrongly")
+	}
+
+	// Ranges are half-open: a date-only listing for 8 March is not on 7 March.
+	tomorrow := Event{StartTime: day(3, 8, 0)}
+	if tomorrow.Overlaps(day(3, 7, 0), day(3, 8, 0)) {
+		t.Error("event starting at the end of the range overlaps it")
+	}
+	if !tomorrow.Overlaps(day(3, 8, 0), day(3, 9, 0)) || !market.Overlaps(day(3, 7, 0), day(3, 8, 0)) {
+		t.Error("date-only event doesn't overlap its own day")
+	}
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SourceRef records one source that listed an event, with its page there.
+type SourceRef struct {
+	Source string `json:"source"`
+	URL    string `json:"url,omitempty"`
+}
+
+// EventID returns a stable identifier for an event: a hash of its
+// normalized name, its date in Leipzig (with the time of day unless it is
+// midnight, so two shows on one day differ) and its venue. venue should be
+// independent of the source's spelling: the venue registry ID, else
+// venue.Key of the name. The same listing gets the same ID on every run.
+func EventID(name string, start time.Time, venue string) string {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		loc = time.UTC
+	}
+	start = start.In(loc)
+	when := start.Format("2006-01-02")
+	if start.Hour() != 0 || start.Minute() != 0 {
+		when += " " + start.Format("15:04")
+	}
+	venue = strings.TrimSpace(strings.TrimSuffix(normalizeForID(venue), "leipzig"))
+	key := normalizeForID(name) + "|" + when + "|" + venue
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// normalizeForID lowercases s and reduces it to words of letters and
+// digits, so punctuation and spacing differences don't change an ID.
+func normalizeForID(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// AddSource records ref in e.Sources unless it is already there.
+func (e *Event) AddSource(ref SourceRef) {
+	for _, r := range e.Sources {
+		if r == ref {
+			return
+		}
+	}
+	// Clip so appending never writes into a slice shared with a copy of e.
+	e.Sources = append(e.Sources[:len(e.Sources):len(e.Sources)], ref)
+}
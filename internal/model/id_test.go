@@ -0,0 +1,56 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventID(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	start := time.Date(2026, 10, 16, 20, 0, 0, 0, loc)
+	id := EventID("Moop Mama – Live!", start, "Conne Island, Leipzig")
+
+	if len(id) != 12 {
+		t.Errorf("ID %q has length %d, want 12", id, len(id))
+	}
+	same := []struct {
+		name  string
+		start time.Time
+		venue string
+	}{
+		{"moop mama live", start, "Conne Island"},
+		{"Moop  Mama - Live", start, "conne island leipzig"},
+		{"Moop Mama – Live!", start.UTC(), "Conne Island, Leipzig"},
+	}
+	for _, s := range same {
+		if got := EventID(s.name, s.start, s.venue); got != id {
+			t.Errorf("EventID(%q, %v, %q) = %s, want %s", s.name, s.start, s.venue, got, id)
+		}
+	}
+	if EventID("Moop Mama – Live!", start.AddDate(0, 0, 1), "Conne Island") == id {
+		t.Error("different day gave the same ID")
+	}
+	if EventID("Moop Mama – Live!", start.Add(-5*time.Hour), "Conne Island") == id {
+		t.Error("different show time gave the same ID")
+	}
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, loc)
+	if EventID("Moop Mama", day, "Conne Island") != EventID("Moop Mama", day.UTC(), "Conne Island") {
+		t.Error("date-only start gave different IDs")
+	}
+}
+
+func TestAddSource(t *testing.T) {
+	var e Event
+	e.AddSource(SourceRef{Source: "leipzig.de", URL: "https://www.leipzig.de/a"})
+	e.AddSource(SourceRef{Source: "leipzig.de", URL: "https://www.leipzig.de/a"})
+	copied := e
+	copied.AddSource(SourceRef{Source: "prinz.de"})
+	e.AddSource(SourceRef{Source: "leipzig-im.de"})
+
+	if len(e.Sources) != 2 || e.Sources[1].Source != "leipzig-im.de" {
+		t.Errorf("e.Sources = %v", e.Sources)
+	}
+	if len(copied.Sources) != 2 || copied.Sources[1].Source != "prinz.de" {
+		t.Errorf("copied.Sources = %v", copied.Sources)
+	}
+}
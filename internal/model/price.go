@@ -0,0 +1,174 @@
+package model
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	PriceFree     = "free"
+	PricePaid     = "paid"
+	PriceDonation = "donation"
+	PriceUnknown  = "unknown"
+)
+
+// Price notes: qualifiers found next to the amounts.
+const (
+	NoteReduced   = "reduced"    // ermäßigt
+	NotePresale   = "presale"    // Vorverkauf (VVK)
+	NoteBoxOffice = "box office" // Abendkasse (AK)
+	NoteDonation  = "donation"   // Spende
+)
+
+// Pricing is Event.Price parsed. Amounts are in Currency.
+type Pricing struct {
+	// Status is PriceFree, PricePaid, PriceDonation or PriceUnknown.
+	Status string `json:"status"`
+	// Min and Max span the regular prices, or the suggested donation.
+	Min      float64 `json:"min,omitempty"`
+	Max      float64 `json:"max,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+	// Reduced is the lowest reduced ("ermäßigt") price, if given.
+	Reduced float64  `json:"reduced,omitempty"`
+	Notes   []string `json:"notes,omitempty"`
+}
+
+// Cheapest returns what the cheapest regular ticket costs, and false if
+// that is unknown. Free events cost 0, and so do donations without a
+// suggested amount.
+func (p Pricing) Cheapest() (float64, bool) {
+	switch p.Status {
+	case PriceFree:
+		return 0, true
+	case PricePaid, PriceDonation:
+		return p.Min, true
+	}
+	return 0, false
+}
+
+var (
+	priceNumber = `(\d+(?:[.,]\d{1,2})?)(?:,-)?`
+	currency    = `(?:€|eur(?:o)?\b)`
+	// Amounts need a currency: "12 €", "€ 12", "10–25 €", "10/8 €".
+	priceRange  = regexp.MustCompile(priceNumber + `\s*` + currency + `?\s*(-|–|/|bis)\s*` + priceNumber + `\s*` + currency)
+	priceSuffix = regexp.MustCompile(priceNumber + `\s*` + currency)
+	pricePrefix = regexp.MustCompile(currency + `\s*` + priceNumber)
+	// A bare number or range is an amount too: "12", "10-15".
+	priceBare = regexp.MustCompile(`^` + priceNumber + `(?:\s*(?:-|–)\s*` + priceNumber + `)?$`)
+
+	freeWords     = regexp.MustCompile(`\b(frei|free|kostenlos|kostenfrei|gratis|umsonst)\b|freier eintritt`)
+	donationWords = regexp.MustCompile(`spende|hutkasse|hut geht rum|pay what you (want|can)|\bpwyw\b`)
+	reducedWords  = regexp.MustCompile(`erm\.|ermäßigt|ermässigt|ermaessigt|\breduced\b|\bconcessions?\b`)
+	presaleWords  = regexp.MustCompile(`\bvvk\b|vorverkauf|presale|pre-sale`)
+	doorWords     = regexp.MustCompile(`\bak\b|abendkasse|tageskasse|\bat the door\b`)
+
+	priceSegments = regexp.MustCompile(`,\s|;|\s/\s|\||\(|\)|\n`)
+)
+
+// ParsePrice parses a source's price text such as "12 €", "10–25 €",
+// "VVK 12 € / AK 15 €, ermäßigt 8 €", "Eintritt frei" or "Spende erbeten".
+func ParsePrice(text string) Pricing {
+	p := Pricing{Status: PriceUnknown}
+	t := strings.ToLower(strings.TrimSpace(text))
+	if t == "" {
+		return p
+	}
+
+	var regular, reduced []float64
+	for _, seg := range priceSegments.Split(t, -1) {
+		amounts, lower := priceAmounts(strings.TrimSpace(seg))
+		switch {
+		case reducedWords.MatchString(seg):
+			p.note(NoteReduced)
+			reduced = append(reduced, amounts...)
+			reduced = append(reduced, lower...)
+		default:
+			regular = append(regular, amounts...)
+			if len(lower) > 0 {
+				p.note(NoteReduced)
+				reduced = append(reduced, lower...)
+			}
+		}
+		if presaleWords.MatchString(seg) {
+			p.note(NotePresale)
+		}
+		if doorWords.MatchString(seg) {
+			p.note(NoteBoxOffice)
+		}
+		if donationWords.MatchString(seg) {
+			p.note(NoteDonation)
+		}
+	}
+	if len(regular) == 0 && len(reduced) > 0 {
+		regular = reduced
+	}
+	if len(regular) > 0 {
+		p.Min, p.Max = slices.Min(regular), slices.Max(regular)
+		p.Currency = "EUR"
+	}
+	if len(reduced) > 0 {
+		p.Reduced = slices.Min(reduced)
+	}
+
+	switch {
+	case freeWords.MatchString(t) && p.Max == 0:
+		p.Status = PriceFree
+	case slices.Contains(p.Notes, NoteDonation):
+		p.Status = PriceDonation
+	case p.Max > 0:
+		p.Status = PricePaid
+	case len(regular) > 0:
+		p.Status = PriceFree // "0 €"
+	}
+	return p
+}
+
+func (p *Pricing) note(n string) {
+	if !slices.Contains(p.Notes, n) {
+		p.Notes = append(p.Notes, n)
+	}
+}
+
+// priceAmounts returns the amounts in one segment of a price text. A
+// falling pair such as "10/8 €" is a regular and a reduced price, so its
+// second amount is returned in reduced rather than as part of a range.
+func priceAmounts(seg string) (result, reduced []float64) {
+	parse := func(s string) (float64, bool) {
+		v, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+		return math.Round(v*100) / 100, err == nil && v < 10000
+	}
+	add := func(s string) {
+		if v, ok := parse(s); ok {
+			result = append(result, v)
+		}
+	}
+	if m := priceBare.FindStringSubmatch(seg); m != nil {
+		add(m[1])
+		if m[2] != "" {
+			add(m[2])
+		}
+		return result, nil
+	}
+	for _, m := range priceRange.FindAllStringSubmatch(seg, -1) {
+		a, okA := parse(m[1])
+		b, okB := parse(m[3])
+		if m[2] == "/" && okA && okB && b < a {
+			result = append(result, a)
+			reduced = append(reduced, b)
+			continue
+		}
+		add(m[1])
+		add(m[3])
+	}
+	seg = priceRange.ReplaceAllString(seg, " ")
+	for _, re := range []*regexp.Regexp{priceSuffix, pricePrefix} {
+		for _, m := range re.FindAllStringSubmatch(seg, -1) {
+			add(m[1])
+		}
+		seg = re.ReplaceAllString(seg, " ")
+	}
+	return result, reduced
+}
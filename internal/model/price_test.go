@@ -0,0 +1,42 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		text string
+		want Pricing
+	}{
+		{"", Pricing{Status: PriceUnknown}},
+		{"siehe Website", Pricing{Status: PriceUnknown}},
+		{"12 €", Pricing{Status: PricePaid, Min: 12, Max: 12, Currency: "EUR"}},
+		{"12,50 EUR", Pricing{Status: PricePaid, Min: 12.5, Max: 12.5, Currency: "EUR"}},
+		{"€ 9", Pricing{Status: PricePaid, Min: 9, Max: 9, Currency: "EUR"}},
+		{"10–25 €", Pricing{Status: PricePaid, Min: 10, Max: 25, Currency: "EUR"}},
+		{"15,- €", Pricing{Status: PricePaid, Min: 15, Max: 15, Currency: "EUR"}},
+		{"12", Pricing{Status: PricePaid, Min: 12, Max: 12, Currency: "EUR"}},
+		{"free", Pricing{Status: PriceFree}},
+		{"Eintritt frei", Pricing{Status: PriceFree}},
+		{"0 €", Pricing{Status: PriceFree, Currency: "EUR"}},
+		{"Barrierefrei, 8 €", Pricing{Status: PricePaid, Min: 8, Max: 8, Currency: "EUR"}},
+		{"Eintritt frei, Spenden erwünscht", Pricing{Status: PriceFree, Notes: []string{NoteDonation}}},
+		{"Eintritt gegen Spende", Pricing{Status: PriceDonation, Notes: []string{NoteDonation}}},
+		{"Spende ab 5 €", Pricing{Status: PriceDonation, Min: 5, Max: 5, Currency: "EUR", Notes: []string{NoteDonation}}},
+		{"VVK 12 € / AK 15 €, ermäßigt 8 €", Pricing{
+			Status: PricePaid, Min: 12, Max: 15, Currency: "EUR", Reduced: 8,
+			Notes: []string{NotePresale, NoteBoxOffice, NoteReduced},
+		}},
+		{"10/8 €", Pricing{Status: PricePaid, Min: 10, Max: 10, Currency: "EUR", Reduced: 8, Notes: []string{NoteReduced}}},
+		{"8/10 €", Pricing{Status: PricePaid, Min: 8, Max: 10, Currency: "EUR"}},
+		{"10 € (erm. 7 €)", Pricing{Status: PricePaid, Min: 10, Max: 10, Currency: "EUR", Reduced: 7, Notes: []string{NoteReduced}}},
+		{"Beginn 20 Uhr, ab 16 Jahren", Pricing{Status: PriceUnknown}},
+	}
+	for _, tt := range tests {
+		if got := ParsePrice(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParsePrice(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
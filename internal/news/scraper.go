@@ -5,33 +5,34 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/PuerkitoBio/goquery"
+
+	"github.com/havocked/leipzig-cli/internal/httpx"
 )
 
 const (
-	baseURL   = "https://www.leipzig.de/newsarchiv"
-	userAgent = "leipzig-cli/1.0 (+https://github.com/havocked/leipzig-cli)"
-	delay     = 500 * time.Millisecond
+	baseURL = "https://www.leipzig.de/newsarchiv"
 )
 
 type FetchOptions struct {
 	Pages    int
 	Category string // API value (e.g. "51")
 	Search   string
+	// Client is used for all requests; nil means httpx.Default().
+	Client *http.Client
 }
 
 func Fetch(opts FetchOptions) ([]Article, error) {
 	if opts.Pages <= 0 {
 		opts.Pages = 1
 	}
+	if opts.Client == nil {
+		opts.Client = httpx.Default()
+	}
 
 	var articles []Article
 	for page := 0; page < opts.Pages; page++ {
-		if page > 0 {
-			time.Sleep(delay)
-		}
 		pageArticles, err := fetchPage(page, opts)
 		if err != nil {
 			return nil, fmt.Errorf("fetching page %d: %w", page+1, err)
@@ -68,9 +69,7 @@ func fetchPage(page int, opts FetchOptions) ([]Article, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", userAgent)
-
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := opts.Client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,22 @@
+package news
+
+import (
+	"testing"
+
+	"github.com/havocked/leipzig-cli/internal/golden"
+	"github.com/havocked/leipzig-cli/internal/httpx"
+)
+
+// Fixtures are refreshed with "leipzig dev record news".
+const fixtures = "testdata/http"
+
+func TestFetchGolden(t *testing.T) {
+	articles, err := Fetch(FetchOptions{Pages: 2, Client: httpx.Replay(fixtures).Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(articles) == 0 {
+		t.Fatal("no articles parsed from recorded pages")
+	}
+	golden.AssertJSON(t, "testdata/fetch.golden.json", articles)
+}
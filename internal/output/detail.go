@@ -0,0 +1,74 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+// Detail prints every field of one event.
+func Detail(w io.Writer, e model.Event) error {
+	emoji := model.CategoryEmoji[e.Category]
+	if emoji == "" {
+		emoji = "📌"
+	}
+	fmt.Fprintf(w, "%s %s\n\n", emoji, e.Name)
+
+	when := e.StartTime.Format("Mon 02 Jan 2006 15:04")
+	if !e.EndTime.IsZero() {
+		if e.EndTime.Format("2006-01-02") == e.StartTime.Format("2006-01-02") {
+			when += " – " + e.EndTime.Format("15:04")
+		} else {
+			when += " – " + e.EndTime.Format("Mon 02 Jan 2006 15:04")
+		}
+	}
+
+	// via names the source of a merged field when it isn't e.Source.
+	via := func(key string) string {
+		if s := e.FieldSources[key]; s != "" && s != e.Source {
+			return " (via " + s + ")"
+		}
+		return ""
+	}
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "%-10s %s\n", label+":", value)
+		}
+	}
+	field("ID", e.ID)
+	field("When", when+via("startTime"))
+	if e.Venue != "" {
+		field("Venue", e.Venue+via("venue"))
+	}
+	if e.Address != "" {
+		field("Address", e.Address+via("address"))
+	}
+	field("District", e.District)
+	field("Category", e.Category)
+	field("Tags", strings.Join(e.Tags, ", "))
+	if e.Price != "" {
+		field("Price", e.Price+via("price"))
+	}
+	if e.Organizer != "" {
+		field("Organizer", e.Organizer+via("organizer"))
+	}
+	field("URL", e.URL)
+	field("Map", e.MapURL)
+	field("OSM", e.OSMURL)
+
+	if len(e.Sources) > 0 {
+		fmt.Fprintln(w, "Sources:")
+		for _, s := range e.Sources {
+			fmt.Fprintf(w, "  %-12s %s\n", s.Source, s.URL)
+		}
+	} else {
+		field("Source", e.Source)
+	}
+
+	if e.Description != "" {
+		fmt.Fprintf(w, "\n%s\n", e.Description)
+	}
+	return nil
+}
@@ -6,8 +6,16 @@ import (
 	"io"
 
 	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/timerange"
 )
 
+// Meta describes a query's result for JSONMeta.
+type Meta struct {
+	// Range is the resolved time range; To is exclusive.
+	Range timerange.Range `json:"range"`
+	Count int             `json:"count"`
+}
+
 func JSON(w io.Writer, events []model.Event) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
@@ -16,3 +24,20 @@ func JSON(w io.Writer, events []model.Event) error {
 	}
 	return nil
 }
+
+// JSONMeta writes {"meta": ..., "events": [...]}.
+func JSONMeta(w io.Writer, meta Meta, events []model.Event) error {
+	if events == nil {
+		events = []model.Event{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(struct {
+		Meta   Meta          `json:"meta"`
+		Events []model.Event `json:"events"`
+	}{meta, events})
+	if err != nil {
+		return fmt.Errorf("json encode: %w", err)
+	}
+	return nil
+}
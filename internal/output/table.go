@@ -5,36 +5,58 @@ import (
 	"io"
 	"strings"
 
+	"github.com/havocked/leipzig-cli/internal/geo"
 	"github.com/havocked/leipzig-cli/internal/model"
 )
 
+// Table prints one event per line. Multi-day events (exhibitions,
+// festivals) follow in a separate "Ongoing" section with their date range.
 func Table(w io.Writer, events []model.Event) error {
 	if len(events) == 0 {
 		fmt.Fprintln(w, "No events found.")
 		return nil
 	}
 
+	var ongoing []model.Event
 	for _, e := range events {
-		date := e.StartTime.Format("Mon 02 Jan")
-		timeStr := e.StartTime.Format("15:04")
-		if e.StartTime.Hour() == 0 && e.StartTime.Minute() == 0 {
-			timeStr = "     "
-		}
-		cat := fmt.Sprintf("%-10s", e.Category)
-		venue := e.Venue
-		if len(venue) > 25 {
-			venue = venue[:22] + "..."
+		if e.MultiDay() {
+			ongoing = append(ongoing, e)
+			continue
 		}
-		name := e.Name
-		if len(name) > 40 {
-			name = name[:37] + "..."
-		}
-		price := e.Price
-		if price == "" {
-			price = ""
+		when := e.StartTime.Format("Mon 02 Jan") + "  " + e.StartTime.Format("15:04")
+		if e.StartTime.Hour() == 0 && e.StartTime.Minute() == 0 {
+			when = e.StartTime.Format("Mon 02 Jan")
 		}
-		_ = strings.TrimSpace
-		fmt.Fprintf(w, "%-11s %5s  %s  %-40s  %-25s  %s\n", date, timeStr, cat, name, venue, price)
+		row(w, when, e)
+	}
+
+	if len(ongoing) == 0 {
+		return nil
+	}
+	if len(ongoing) < len(events) {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "Ongoing:")
+	for _, e := range ongoing {
+		last := e.End().Add(-1) // End is exclusive for date-only events
+		row(w, e.StartTime.Format("02 Jan")+" – "+last.Format("02 Jan"), e)
 	}
 	return nil
 }
+
+func row(w io.Writer, when string, e model.Event) {
+	cat := fmt.Sprintf("%-10s", e.Category)
+	venue := e.Venue
+	if len(venue) > 25 {
+		venue = venue[:22] + "..."
+	}
+	name := e.Name
+	if len(name) > 40 {
+		name = name[:37] + "..."
+	}
+	price := e.Price
+	if e.DistanceMeters != nil {
+		price = strings.TrimSpace(geo.FormatDistance(float64(*e.DistanceMeters)) + "  " + price)
+	}
+	fmt.Fprintf(w, "%-17s  %s  %-40s  %-25s  %s\n", when, cat, name, venue, price)
+}
@@ -0,0 +1,58 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+func TestTableOngoing(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	sat := time.Date(2026, 10, 17, 0, 0, 0, 0, loc)
+	events := []model.Event{
+		{Name: "Exhibition", StartTime: time.Date(2026, 9, 1, 0, 0, 0, 0, loc), EndTime: time.Date(2026, 11, 30, 0, 0, 0, 0, loc)},
+		{Name: "Flea market", StartTime: sat},
+		{Name: "Festival", StartTime: sat.Add(-6 * time.Hour), EndTime: sat.Add(48 * time.Hour)},
+		{Name: "Concert", StartTime: sat.Add(20 * time.Hour), EndTime: sat.Add(22 * time.Hour)},
+	}
+	var buf bytes.Buffer
+	if err := Table(&buf, events); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		// The date column and the name, without the padding.
+		if f := strings.Fields(line); len(f) > 0 {
+			got = append(got, strings.Join(f, " "))
+		}
+	}
+	want := []string{
+		"Sat 17 Oct Flea market",
+		"Sat 17 Oct 20:00 Concert",
+		"Ongoing:",
+		// An all-day end date is the last day; a timed end at midnight
+		// is over before that day starts.
+		"01 Sep – 30 Nov Exhibition",
+		"16 Oct – 18 Oct Festival",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTableNoOngoing(t *testing.T) {
+	var buf bytes.Buffer
+	Table(&buf, []model.Event{{Name: "Concert", StartTime: time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)}})
+	if strings.Contains(buf.String(), "Ongoing") {
+		t.Errorf("Ongoing section without multi-day events:\n%s", buf.String())
+	}
+}
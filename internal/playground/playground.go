@@ -13,6 +13,11 @@ type Playground struct {
 	Subdistrict string `json:"subdistrict"`
 	DetailURL   string `json:"detail_url"`
 	MapURL      string `json:"map_url"`
+	// Lat, Lon, OSMURL and GeoURI are set when the address is resolved.
+	Lat    float64 `json:"lat,omitempty"`
+	Lon    float64 `json:"lon,omitempty"`
+	OSMURL string  `json:"osm_url,omitempty"`
+	GeoURI string  `json:"geo_uri,omitempty"`
 }
 
 func MakeMapURL(address string) string {
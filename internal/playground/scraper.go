@@ -5,21 +5,25 @@ import (
 	"net/http"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/PuerkitoBio/goquery"
+
+	"github.com/havocked/leipzig-cli/internal/httpx"
 )
 
 const (
-	baseURL   = "https://www.leipzig.de/kultur-und-freizeit/spielplaetze"
-	userAgent = "leipzig-cli/1.0 (+https://github.com/havocked/leipzig-cli)"
-	pageSize  = 25
-	delay     = 500 * time.Millisecond
+	baseURL  = "https://www.leipzig.de/kultur-und-freizeit/spielplaetze"
+	pageSize = 25
 )
 
 func FetchAll() ([]Playground, error) {
+	return FetchAllWith(httpx.Default())
+}
+
+// FetchAllWith is FetchAll using client for all requests.
+func FetchAllWith(client *http.Client) ([]Playground, error) {
 	// Fetch page 1 to get total count
-	playgrounds, total, err := fetchPage(1)
+	playgrounds, total, err := fetchPage(client, 1)
 	if err != nil {
 		return nil, fmt.Errorf("fetching page 1: %w", err)
 	}
@@ -34,8 +38,7 @@ func FetchAll() ([]Playground, error) {
 	totalPages := (total + pageSize - 1) / pageSize
 
 	for page := 2; page <= totalPages; page++ {
-		time.Sleep(delay)
-		pg, _, err := fetchPage(page)
+		pg, _, err := fetchPage(client, page)
 		if err != nil {
 			return nil, fmt.Errorf("fetching page %d: %w", page, err)
 		}
@@ -51,16 +54,14 @@ func FetchAll() ([]Playground, error) {
 	return playgrounds, nil
 }
 
-func fetchPage(page int) ([]Playground, int, error) {
+func fetchPage(client *http.Client, page int) ([]Playground, int, error) {
 	pageURL := fmt.Sprintf("%s?tx_lepurpose[filtered]=1&tx_lepurpose[filter][page]=%d#c313548", baseURL, page)
 
 	req, err := http.NewRequest("GET", pageURL, nil)
 	if err != nil {
 		return nil, 0, err
 	}
-	req.Header.Set("User-Agent", userAgent)
-
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -92,6 +93,9 @@ func fetchPage(page int) ([]Playground, int, error) {
 		}
 	})
 
+	if len(playgrounds) == 0 && total > 0 {
+		return nil, 0, fmt.Errorf("page reports %d results but no playground cards matched (markup changed?)", total)
+	}
 	return playgrounds, total, nil
 }
 
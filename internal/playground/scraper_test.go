@@ -0,0 +1,22 @@
+package playground
+
+import (
+	"testing"
+
+	"github.com/havocked/leipzig-cli/internal/golden"
+	"github.com/havocked/leipzig-cli/internal/httpx"
+)
+
+// Fixtures are refreshed with "leipzig dev record playgrounds".
+const fixtures = "testdata/http"
+
+func TestFetchAllGolden(t *testing.T) {
+	playgrounds, err := FetchAllWith(httpx.Replay(fixtures).Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(playgrounds) == 0 {
+		t.Fatal("no playgrounds parsed from recorded pages")
+	}
+	golden.AssertJSON(t, "testdata/fetchall.golden.json", playgrounds)
+}
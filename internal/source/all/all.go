@@ -0,0 +1,13 @@
+// Package all registers every built-in source adapter. Import it for its
+// side effects:
+//
+//	import _ "github.com/havocked/leipzig-cli/internal/source/all"
+package all
+
+import (
+	_ "github.com/havocked/leipzig-cli/internal/source/ical"
+	_ "github.com/havocked/leipzig-cli/internal/source/jsonld"
+	_ "github.com/havocked/leipzig-cli/internal/source/leipzigde"
+	_ "github.com/havocked/leipzig-cli/internal/source/leipzigim"
+	_ "github.com/havocked/leipzig-cli/internal/source/prinzde"
+)
@@ -0,0 +1,269 @@
+// Package ical is a source adapter for iCalendar (.ics) feeds. It reads any
+// number of feed URLs or local files and expands recurring events into the
+// requested window.
+package ical
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/httpx"
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+// defaultHorizon bounds recurrence expansion when Fetch gets no end time.
+const defaultHorizon = 365 * 24 * time.Hour
+
+// categoryAliases maps common CATEGORIES values to canonical categories.
+var categoryAliases = map[string]string{
+	"concert":     model.CategoryConcert,
+	"music":       model.CategoryConcert,
+	"musik":       model.CategoryConcert,
+	"konzert":     model.CategoryConcert,
+	"theater":     model.CategoryTheater,
+	"theatre":     model.CategoryTheater,
+	"comedy":      model.CategoryTheater,
+	"exhibition":  model.CategoryExhibition,
+	"ausstellung": model.CategoryExhibition,
+	"family":      model.CategoryFamily,
+	"kinder":      model.CategoryFamily,
+	"market":      model.CategoryMarket,
+	"markt":       model.CategoryMarket,
+	"sport":       model.CategorySport,
+	"culture":     model.CategoryCulture,
+	"kultur":      model.CategoryCulture,
+	"lecture":     model.CategoryCulture,
+	"vortrag":     model.CategoryCulture,
+	"film":        model.CategoryCulture,
+	"nightlife":   model.CategoryNightlife,
+	"party":       model.CategoryNightlife,
+}
+
+type Source struct {
+	client *http.Client
+	feeds  []string
+}
+
+// New returns a source reading the given feeds. Each feed is an http(s) or
+// webcal URL, or a path to a local .ics file.
+func New(feeds ...string) *Source {
+	return &Source{client: httpx.Default(), feeds: feeds}
+}
+
+func init() {
+	source.Register("ical", func(cfg source.Config) (source.Source, error) { return New(cfg.URLs...), nil })
+}
+
+func (s *Source) ID() string { return "ical" }
+
+func (s *Source) Describe() source.Description {
+	return source.Description{
+		Text:       "iCalendar (.ics) feeds listed under sources.ical.urls",
+		Categories: source.MappedCategories(categoryAliases),
+		NeedsURLs:  true,
+	}
+}
+
+func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	if to.IsZero() {
+		base := from
+		if base.IsZero() {
+			base = time.Now()
+		}
+		to = base.Add(defaultHorizon)
+	}
+
+	var (
+		all    []model.Event
+		failed int
+	)
+	for _, feed := range s.feeds {
+		events, err := s.fetchFeed(ctx, feed, from, to, loc)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			fmt.Fprintf(os.Stderr, "warning: ical feed %s failed: %v\n", feed, err)
+			failed++
+			continue
+		}
+		all = append(all, events...)
+	}
+	if failed > 0 && failed == len(s.feeds) {
+		return nil, fmt.Errorf("ical: all %d feeds failed", failed)
+	}
+	return all, nil
+}
+
+func (s *Source) fetchFeed(ctx context.Context, feed string, from, to time.Time, loc *time.Location) ([]model.Event, error) {
+	rc, err := s.open(ctx, feed)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	vevents, skipped, err := parseCalendar(rc, loc)
+	if err != nil {
+		return nil, err
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "warning: ical feed %s: skipped %d malformed events\n", feed, skipped)
+	}
+	return expand(vevents, from, to), nil
+}
+
+func (s *Source) open(ctx context.Context, feed string) (io.ReadCloser, error) {
+	u := feed
+	if strings.HasPrefix(u, "webcal://") {
+		u = "https://" + strings.TrimPrefix(u, "webcal://")
+	}
+	if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+		return source.OpenFile(u)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar, */*;q=0.5")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// expand turns parsed VEVENTs into events overlapping [from, to], expanding
+// RRULE/RDATE recurrences and applying EXDATE and RECURRENCE-ID overrides.
+func expand(vevents []vevent, from, to time.Time) []model.Event {
+	// Overridden instances, keyed by UID + original start.
+	overridden := make(map[string]bool)
+	for _, v := range vevents {
+		if !v.recurrenceID.IsZero() {
+			overridden[v.uid+"|"+v.recurrenceID.UTC().Format(time.RFC3339)] = true
+		}
+	}
+
+	var events []model.Event
+	for _, v := range vevents {
+		if v.start.IsZero() {
+			continue
+		}
+		length := v.length()
+
+		starts := []time.Time{v.start}
+		if v.rrule != "" && v.recurrenceID.IsZero() {
+			r, err := parseRRule(v.rrule, v.start.Location())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: ical: %s: %v\n", v.summary, err)
+			} else {
+				starts = r.occurrences(v.start, from.Add(-length), to)
+			}
+		}
+		starts = append(starts, v.rdates...)
+
+		excluded := make(map[int64]bool)
+		for _, ex := range v.exdates {
+			excluded[ex.Unix()] = true
+		}
+
+		for _, start := range starts {
+			if excluded[start.Unix()] {
+				continue
+			}
+			if v.recurrenceID.IsZero() && overridden[v.uid+"|"+start.UTC().Format(time.RFC3339)] {
+				continue
+			}
+			end := start.Add(length)
+			if !to.IsZero() && start.After(to) {
+				continue
+			}
+			if !from.IsZero() && start.Before(from) && !end.After(from) {
+				continue
+			}
+			if v.status == "CANCELLED" {
+				continue
+			}
+			events = append(events, v.toEvent(start, length))
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartTime.Before(events[j].StartTime)
+	})
+	return events
+}
+
+// length returns the event's duration from DTEND or DURATION.
+func (v vevent) length() time.Duration {
+	switch {
+	case !v.end.IsZero() && v.end.After(v.start):
+		return v.end.Sub(v.start)
+	case v.duration > 0:
+		return v.duration
+	case v.allDay:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+func (v vevent) toEvent(start time.Time, length time.Duration) model.Event {
+	e := model.Event{
+		Name:        v.summary,
+		Description: v.description,
+		StartTime:   start,
+		URL:         v.url,
+		Tags:        v.categories,
+		Source:      "ical",
+	}
+
+	switch {
+	case v.allDay && length > 24*time.Hour:
+		// DTEND of all-day events is exclusive; store the last day.
+		e.EndTime = start.Add(length).AddDate(0, 0, -1)
+	case !v.allDay && length > 0:
+		e.EndTime = start.Add(length)
+	}
+
+	e.Venue, e.Address = splitLocation(v.location)
+	e.Category = mapCategories(v.categories)
+	if e.Category == model.CategoryOther {
+		e.Category = model.InferCategory(e.Name, e.Venue)
+	}
+	return e
+}
+
+// splitLocation splits "Felsenkeller, Karl-Heine-Str. 32, 04229 Leipzig"
+// into venue name and address.
+func splitLocation(loc string) (venue, address string) {
+	loc = strings.Join(strings.Fields(loc), " ")
+	venue, address, _ = strings.Cut(loc, ",")
+	return strings.TrimSpace(venue), strings.TrimSpace(address)
+}
+
+func mapCategories(cats []string) string {
+	for _, c := range cats {
+		if cat, ok := categoryAliases[strings.ToLower(c)]; ok {
+			return cat
+		}
+	}
+	for _, c := range cats {
+		if cat := model.InferCategory(c, ""); cat != model.CategoryOther {
+			return cat
+		}
+	}
+	return model.CategoryOther
+}
@@ -0,0 +1,161 @@
+package ical
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+func TestFetchFile(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, loc)
+
+	events, err := New("testdata/feed.ics").Fetch(context.Background(), from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, e := range events {
+		got = append(got, e.StartTime.In(loc).Format("01-02 15:04")+" "+e.Name)
+	}
+	want := []string{
+		"03-01 00:00 Ausstellung: Leipziger Schule",
+		"03-02 20:00 Jazz am Montag",
+		"03-07 08:00 Flohmarkt",
+		"03-09 20:00 Jazz am Montag",
+		"03-10 18:00 Ringvorlesung Stadtgeschichte",
+		"03-23 21:00 Jazz am Montag (Spezial)",
+		"03-30 20:00 Jazz am Montag",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d events:\n%v\nwant:\n%v", len(got), got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	jazz := events[1]
+	if jazz.Venue != "Moritzbastei" || jazz.Address != "Kurt-Masur-Platz 1, 04109 Leipzig" {
+		t.Errorf("location = %q / %q", jazz.Venue, jazz.Address)
+	}
+	if jazz.Category != model.CategoryConcert {
+		t.Errorf("category = %q, want concert", jazz.Category)
+	}
+	if jazz.Description != "Offene Session.\nEintritt frei." {
+		t.Errorf("description = %q", jazz.Description)
+	}
+	if !jazz.EndTime.Equal(time.Date(2026, 3, 2, 22, 30, 0, 0, loc)) {
+		t.Errorf("end = %v", jazz.EndTime)
+	}
+
+	expo := events[0]
+	if !expo.EndTime.Equal(time.Date(2026, 4, 10, 0, 0, 0, 0, loc)) {
+		t.Errorf("all-day end = %v, want last day 2026-04-10", expo.EndTime)
+	}
+	if expo.Category != model.CategoryExhibition {
+		t.Errorf("inferred category = %q, want exhibition", expo.Category)
+	}
+
+	if talk := events[4]; !talk.EndTime.Equal(talk.StartTime.Add(90 * time.Minute)) {
+		t.Errorf("duration end = %v", talk.EndTime)
+	}
+}
+
+func TestRRuleAcrossDST(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	r, err := parseRRule("FREQ=DAILY;UNTIL=20260331T000000Z", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 3, 28, 19, 0, 0, 0, loc)
+	got := r.occurrences(start, start, start.AddDate(0, 0, 10))
+	if len(got) != 3 {
+		t.Fatalf("got %d occurrences, want 3: %v", len(got), got)
+	}
+	for _, o := range got {
+		if o.Hour() != 19 {
+			t.Errorf("occurrence %v not at 19:00 local", o)
+		}
+	}
+}
+
+func TestRRuleMonthlyLastFriday(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	r, err := parseRRule("FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 1, 30, 22, 0, 0, 0, loc)
+	got := r.occurrences(start, start, start.AddDate(1, 0, 0))
+	want := []int{30, 27, 27} // Jan 30, Feb 27, Mar 27
+	if len(got) != len(want) {
+		t.Fatalf("got %v", got)
+	}
+	for i, d := range want {
+		if got[i].Day() != d {
+			t.Errorf("occurrence %d = %v, want day %d", i, got[i], d)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT1H30M": 90 * time.Minute,
+		"P1D":     24 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"P1DT2H":  26 * time.Hour,
+		"-PT15M":  -15 * time.Minute,
+	}
+	for in, want := range tests {
+		got, err := parseDuration(in)
+		if err != nil || got != want {
+			t.Errorf("parseDuration(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+}
+
+func TestParseCalendarSkipsBrokenEvents(t *testing.T) {
+	const feed = `BEGIN:VCALENDAR
+X-STRAY-LINE-WITHOUT-COLON
+BEGIN:VEVENT
+SUMMARY:Lesung
+DTSTART:20260305T190000
+END:VEVENT
+BEGIN:VEVENT
+SUMMARY:Kaputter Termin
+DTSTART:2026-03-06 20:00
+END:VEVENT
+BEGIN:VEVENT
+SUMMARY:Ohne Doppelpunkt
+DTSTART:20260307T200000
+DURATION PT2H
+END:VEVENT
+BEGIN:VEVENT
+SUMMARY:Konzert
+DTSTART:20260308T200000
+DURATION:PT2H
+END:VEVENT
+END:VCALENDAR
+`
+	vevents, skipped, err := parseCalendar(strings.NewReader(feed), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, v := range vevents {
+		got = append(got, v.summary)
+	}
+	if len(got) != 2 || got[0] != "Lesung" || got[1] != "Konzert" || skipped != 2 {
+		t.Fatalf("got %v, skipped %d; want [Lesung Konzert], skipped 2", got, skipped)
+	}
+	if vevents[1].duration != 2*time.Hour {
+		t.Errorf("duration = %v", vevents[1].duration)
+	}
+}
@@ -0,0 +1,316 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// property is one content line: NAME;PARAM=VALUE:value
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// vevent holds the properties of one VEVENT we care about.
+type vevent struct {
+	uid          string
+	summary      string
+	description  string
+	location     string
+	url          string
+	status       string
+	categories   []string
+	start        time.Time
+	end          time.Time
+	allDay       bool
+	duration     time.Duration
+	rrule        string
+	rdates       []time.Time
+	exdates      []time.Time
+	recurrenceID time.Time
+}
+
+// parseCalendar reads all VEVENTs from an iCalendar stream. Floating times
+// are interpreted in loc. A VEVENT with a malformed line or an unreadable
+// date or duration is left out and counted in skipped; malformed lines
+// outside VEVENTs are ignored.
+func parseCalendar(r io.Reader, loc *time.Location) (events []vevent, skipped int, err error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		cur    *vevent
+		broken bool // cur has a line we couldn't read
+		depth  int  // nesting inside VEVENT (VALARM etc.)
+	)
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		p, err := parseProperty(line)
+		if err != nil {
+			if cur != nil {
+				broken = true
+			}
+			continue
+		}
+
+		switch p.name {
+		case "BEGIN":
+			if strings.EqualFold(p.value, "VEVENT") && cur == nil {
+				cur = &vevent{}
+				continue
+			}
+			if cur != nil {
+				depth++
+			}
+			continue
+		case "END":
+			if cur == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if strings.EqualFold(p.value, "VEVENT") {
+				if broken {
+					skipped++
+				} else {
+					events = append(events, *cur)
+				}
+				cur, broken = nil, false
+			}
+			continue
+		}
+		if cur == nil || depth > 0 {
+			continue
+		}
+
+		switch p.name {
+		case "UID":
+			cur.uid = p.value
+		case "SUMMARY":
+			cur.summary = unescape(p.value)
+		case "DESCRIPTION":
+			cur.description = unescape(p.value)
+		case "LOCATION":
+			cur.location = unescape(p.value)
+		case "URL":
+			cur.url = p.value
+		case "STATUS":
+			cur.status = strings.ToUpper(p.value)
+		case "CATEGORIES":
+			for _, c := range splitEscaped(p.value, ',') {
+				if c = strings.TrimSpace(unescape(c)); c != "" {
+					cur.categories = append(cur.categories, c)
+				}
+			}
+		case "DTSTART":
+			cur.start, cur.allDay, err = parseTime(p, loc)
+		case "DTEND":
+			cur.end, _, err = parseTime(p, loc)
+		case "DURATION":
+			cur.duration, err = parseDuration(p.value)
+		case "RRULE":
+			cur.rrule = p.value
+		case "RDATE":
+			cur.rdates, err = appendTimes(cur.rdates, p, loc)
+		case "EXDATE":
+			cur.exdates, err = appendTimes(cur.exdates, p, loc)
+		case "RECURRENCE-ID":
+			cur.recurrenceID, _, err = parseTime(p, loc)
+		}
+		if err != nil {
+			broken = true
+		}
+	}
+	return events, skipped, nil
+}
+
+// unfold joins continuation lines (RFC 5545 §3.1).
+func unfold(r io.Reader) ([]string, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("ical: read: %w", err)
+	}
+	return lines, nil
+}
+
+func parseProperty(line string) (property, error) {
+	// The value starts at the first colon outside a quoted parameter value.
+	inQuote := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			inQuote = !inQuote
+		} else if c == ':' && !inQuote {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return property{}, fmt.Errorf("malformed line %q", line)
+	}
+
+	head := splitEscaped(line[:colon], ';')
+	p := property{name: strings.ToUpper(head[0]), value: line[colon+1:]}
+	for _, param := range head[1:] {
+		k, v, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		if p.params == nil {
+			p.params = make(map[string]string)
+		}
+		p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return p, nil
+}
+
+// splitEscaped splits s on sep, ignoring backslash-escaped and quoted separators.
+func splitEscaped(s string, sep rune) []string {
+	var (
+		parts   []string
+		b       strings.Builder
+		escaped bool
+		inQuote bool
+	)
+	for _, c := range s {
+		switch {
+		case escaped:
+			b.WriteRune('\\')
+			b.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			inQuote = !inQuote
+			b.WriteRune(c)
+		case c == sep && !inQuote:
+			parts = append(parts, b.String())
+			b.Reset()
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return append(parts, b.String())
+}
+
+var textUnescaper = strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescape(s string) string {
+	return strings.TrimSpace(textUnescaper.Replace(s))
+}
+
+// parseTime reads a DATE or DATE-TIME value, honouring TZID and UTC "Z".
+func parseTime(p property, loc *time.Location) (t time.Time, allDay bool, err error) {
+	return parseTimeValue(p.value, p.params, loc)
+}
+
+func parseTimeValue(v string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	switch {
+	case params["VALUE"] == "DATE" || len(v) == 8:
+		t, err := time.ParseInLocation("20060102", v, loc)
+		return t, true, err
+	case strings.HasSuffix(v, "Z"):
+		t, err := time.Parse("20060102T150405Z", v)
+		return t.In(loc), false, err
+	default:
+		t, err := time.ParseInLocation("20060102T150405", v, loc)
+		return t, false, err
+	}
+}
+
+func appendTimes(list []time.Time, p property, loc *time.Location) ([]time.Time, error) {
+	if p.params["VALUE"] == "PERIOD" {
+		return list, nil
+	}
+	for _, v := range strings.Split(p.value, ",") {
+		t, _, err := parseTimeValue(strings.TrimSpace(v), p.params, loc)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, t)
+	}
+	return list, nil
+}
+
+// parseDuration reads an RFC 5545 duration such as "PT1H30M" or "P2D".
+func parseDuration(s string) (time.Duration, error) {
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	s = s[1:]
+
+	var (
+		d      time.Duration
+		num    int
+		digits bool
+		inTime bool
+	)
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			num = num*10 + int(c-'0')
+			digits = true
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		}
+		if !digits {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		switch {
+		case c == 'W':
+			d += time.Duration(num) * 7 * 24 * time.Hour
+		case c == 'D':
+			d += time.Duration(num) * 24 * time.Hour
+		case c == 'H' && inTime:
+			d += time.Duration(num) * time.Hour
+		case c == 'M' && inTime:
+			d += time.Duration(num) * time.Minute
+		case c == 'S' && inTime:
+			d += time.Duration(num) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		num, digits = 0, false
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
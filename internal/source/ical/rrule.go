@@ -0,0 +1,296 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expansion limits for unbounded or pathological rules.
+const (
+	maxOccurrences = 5000
+	maxPeriods     = 100000
+)
+
+// rrule is the subset of RFC 5545 recurrence rules we expand.
+type rrule struct {
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	byDay      []weekdayNum
+	byMonthDay []int
+	byMonth    []time.Month
+}
+
+// weekdayNum is a BYDAY entry such as "MO" or "-1FR".
+type weekdayNum struct {
+	n   int // 0 means every such weekday in the period
+	day time.Weekday
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+func parseRRule(s string, loc *time.Location) (rrule, error) {
+	r := rrule{interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(k) {
+		case "FREQ":
+			r.freq = strings.ToUpper(v)
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return r, fmt.Errorf("invalid INTERVAL %q", v)
+			}
+			r.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return r, fmt.Errorf("invalid COUNT %q", v)
+			}
+			r.count = n
+		case "UNTIL":
+			t, allDay, err := parseTimeValue(v, nil, loc)
+			if err != nil {
+				return r, fmt.Errorf("invalid UNTIL %q", v)
+			}
+			if allDay {
+				t = t.AddDate(0, 0, 1).Add(-time.Second)
+			}
+			r.until = t
+		case "BYDAY":
+			for _, d := range strings.Split(v, ",") {
+				wn, err := parseWeekdayNum(d)
+				if err != nil {
+					return r, err
+				}
+				r.byDay = append(r.byDay, wn)
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(v, ",") {
+				n, err := strconv.Atoi(d)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return r, fmt.Errorf("invalid BYMONTHDAY %q", d)
+				}
+				r.byMonthDay = append(r.byMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, m := range strings.Split(v, ",") {
+				n, err := strconv.Atoi(m)
+				if err != nil || n < 1 || n > 12 {
+					return r, fmt.Errorf("invalid BYMONTH %q", m)
+				}
+				r.byMonth = append(r.byMonth, time.Month(n))
+			}
+		}
+	}
+	switch r.freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return r, fmt.Errorf("unsupported FREQ %q", r.freq)
+	}
+	return r, nil
+}
+
+func parseWeekdayNum(s string) (weekdayNum, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) < 2 {
+		return weekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	day, ok := weekdays[s[len(s)-2:]]
+	if !ok {
+		return weekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	wn := weekdayNum{day: day}
+	if prefix := s[:len(s)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil {
+			return weekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+		}
+		wn.n = n
+	}
+	return wn, nil
+}
+
+// occurrences returns the start times generated by r from dtstart that fall
+// within [after, limit]. COUNT is applied from dtstart, not from after.
+func (r rrule) occurrences(dtstart, after, limit time.Time) []time.Time {
+	if !r.until.IsZero() && r.until.Before(limit) {
+		limit = r.until
+	}
+
+	var out []time.Time
+	emitted := 0
+	for period := 0; period < maxPeriods && len(out) < maxOccurrences; period++ {
+		candidates, periodStart := r.expandPeriod(dtstart, period*r.interval)
+		if periodStart.After(limit) {
+			break
+		}
+		for _, t := range candidates {
+			if t.Before(dtstart) || t.After(limit) {
+				continue
+			}
+			if r.count > 0 && emitted >= r.count {
+				return out
+			}
+			emitted++
+			if !t.Before(after) {
+				out = append(out, t)
+			}
+		}
+	}
+	return out
+}
+
+// expandPeriod returns the sorted candidate starts in the period that is
+// offset periods after dtstart's, along with the period's first instant.
+func (r rrule) expandPeriod(dtstart time.Time, offset int) ([]time.Time, time.Time) {
+	loc := dtstart.Location()
+	h, m, s := dtstart.Clock()
+	at := func(y int, mo time.Month, d int) time.Time {
+		return time.Date(y, mo, d, h, m, s, 0, loc)
+	}
+
+	var days []time.Time
+	var periodStart time.Time
+
+	switch r.freq {
+	case "DAILY":
+		d := dtstart.AddDate(0, 0, offset)
+		periodStart = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+		if r.matchesFilters(d) {
+			days = append(days, d)
+		}
+
+	case "WEEKLY":
+		// Weeks start on Monday (WKST=MO).
+		monday := dtstart.AddDate(0, 0, -((int(dtstart.Weekday())+6)%7)+7*offset)
+		periodStart = time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, loc)
+		byDay := r.byDay
+		if len(byDay) == 0 {
+			byDay = []weekdayNum{{day: dtstart.Weekday()}}
+		}
+		for _, wd := range byDay {
+			d := monday.AddDate(0, 0, (int(wd.day)+6)%7)
+			if r.matchesMonth(d) {
+				days = append(days, at(d.Year(), d.Month(), d.Day()))
+			}
+		}
+
+	case "MONTHLY":
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(offset), 1, 0, 0, 0, 0, loc)
+		periodStart = first
+		if r.matchesMonth(first) {
+			days = r.daysInMonth(first, dtstart, at)
+		}
+
+	case "YEARLY":
+		year := dtstart.Year() + offset
+		periodStart = time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+		months := r.byMonth
+		if len(months) == 0 {
+			months = []time.Month{dtstart.Month()}
+		}
+		for _, mo := range months {
+			days = append(days, r.daysInMonth(time.Date(year, mo, 1, 0, 0, 0, 0, loc), dtstart, at)...)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days, periodStart
+}
+
+// daysInMonth applies BYMONTHDAY/BYDAY within the month starting at first.
+func (r rrule) daysInMonth(first, dtstart time.Time, at func(int, time.Month, int) time.Time) []time.Time {
+	last := first.AddDate(0, 1, -1).Day()
+	var days []time.Time
+
+	switch {
+	case len(r.byMonthDay) > 0:
+		for _, md := range r.byMonthDay {
+			d := md
+			if d < 0 {
+				d = last + 1 + d
+			}
+			if d >= 1 && d <= last {
+				days = append(days, at(first.Year(), first.Month(), d))
+			}
+		}
+	case len(r.byDay) > 0:
+		for _, wd := range r.byDay {
+			var matches []int
+			for d := 1; d <= last; d++ {
+				if time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, first.Location()).Weekday() == wd.day {
+					matches = append(matches, d)
+				}
+			}
+			switch {
+			case wd.n == 0:
+				for _, d := range matches {
+					days = append(days, at(first.Year(), first.Month(), d))
+				}
+			case wd.n > 0 && wd.n <= len(matches):
+				days = append(days, at(first.Year(), first.Month(), matches[wd.n-1]))
+			case wd.n < 0 && -wd.n <= len(matches):
+				days = append(days, at(first.Year(), first.Month(), matches[len(matches)+wd.n]))
+			}
+		}
+	default:
+		// Months without dtstart's day (e.g. the 31st) are skipped.
+		if dtstart.Day() <= last {
+			days = append(days, at(first.Year(), first.Month(), dtstart.Day()))
+		}
+	}
+	return days
+}
+
+func (r rrule) matchesFilters(t time.Time) bool {
+	if !r.matchesMonth(t) {
+		return false
+	}
+	if len(r.byDay) > 0 {
+		ok := false
+		for _, wd := range r.byDay {
+			if wd.day == t.Weekday() {
+				ok = true
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if len(r.byMonthDay) > 0 {
+		last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+		ok := false
+		for _, md := range r.byMonthDay {
+			if md == t.Day() || (md < 0 && last+1+md == t.Day()) {
+				ok = true
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (r rrule) matchesMonth(t time.Time) bool {
+	if len(r.byMonth) == 0 {
+		return true
+	}
+	for _, m := range r.byMonth {
+		if m == t.Month() {
+			return true
+		}
+	}
+	return false
+}
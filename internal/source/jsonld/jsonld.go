@@ -0,0 +1,482 @@
+// Package jsonld is a source adapter that extracts schema.org Event objects
+// from the JSON-LD blocks embedded in venue web pages.
+package jsonld
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/havocked/leipzig-cli/internal/httpx"
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+// typeToCategory maps schema.org Event subtypes to canonical categories.
+var typeToCategory = map[string]string{
+	"MusicEvent":       model.CategoryConcert,
+	"TheaterEvent":     model.CategoryTheater,
+	"ComedyEvent":      model.CategoryTheater,
+	"DanceEvent":       model.CategoryTheater,
+	"ExhibitionEvent":  model.CategoryExhibition,
+	"VisualArtsEvent":  model.CategoryExhibition,
+	"ChildrensEvent":   model.CategoryFamily,
+	"SportsEvent":      model.CategorySport,
+	"LiteraryEvent":    model.CategoryCulture,
+	"EducationEvent":   model.CategoryCulture,
+	"ScreeningEvent":   model.CategoryCulture,
+	"Festival":         model.CategoryCulture,
+	"SaleEvent":        model.CategoryMarket,
+	"FoodEvent":        model.CategoryOther,
+	"SocialEvent":      model.CategoryOther,
+	"BusinessEvent":    model.CategoryOther,
+	"PublicationEvent": model.CategoryOther,
+}
+
+type Source struct {
+	client *http.Client
+	pages  []string
+}
+
+// New returns a source reading the given venue pages. Each page is an
+// http(s) URL or a path to a saved HTML file.
+func New(pages ...string) *Source {
+	return &Source{client: httpx.Default(), pages: pages}
+}
+
+func init() {
+	source.Register("jsonld", func(cfg source.Config) (source.Source, error) { return New(cfg.URLs...), nil })
+}
+
+func (s *Source) ID() string { return "jsonld" }
+
+func (s *Source) Describe() source.Description {
+	return source.Description{
+		Text:       "schema.org JSON-LD events from pages listed under sources.jsonld.urls",
+		Categories: source.MappedCategories(typeToCategory),
+		NeedsURLs:  true,
+	}
+}
+
+func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+
+	var (
+		all    []model.Event
+		failed int
+		seen   = make(map[string]bool)
+	)
+	for _, page := range s.pages {
+		events, err := s.fetchPage(ctx, page, loc)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			fmt.Fprintf(os.Stderr, "warning: jsonld page %s failed: %v\n", page, err)
+			failed++
+			continue
+		}
+		for _, e := range events {
+			if !e.Overlaps(from, to) {
+				continue
+			}
+			// Venue pages often repeat the same event in several blocks.
+			key := e.Name + "|" + e.StartTime.String() + "|" + e.Venue
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			all = append(all, e)
+		}
+	}
+	if failed > 0 && failed == len(s.pages) {
+		return nil, fmt.Errorf("jsonld: all %d pages failed", failed)
+	}
+	return all, nil
+}
+
+func (s *Source) fetchPage(ctx context.Context, page string, loc *time.Location) ([]model.Event, error) {
+	if !strings.HasPrefix(page, "http://") && !strings.HasPrefix(page, "https://") {
+		f, err := source.OpenFile(page)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return extract(f, "", loc)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", page, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return extract(resp.Body, page, loc)
+}
+
+// extract parses every JSON-LD block in an HTML page and returns the events
+// found in it. pageURL is used as the event URL when an event has none.
+func extract(r io.Reader, pageURL string, loc *time.Location) ([]model.Event, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("jsonld: parse HTML: %w", err)
+	}
+
+	var events []model.Event
+	doc.Find(`script[type="application/ld+json"]`).Each(func(_ int, script *goquery.Selection) {
+		var data any
+		if err := json.Unmarshal([]byte(script.Text()), &data); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: jsonld: skipping invalid block: %v\n", err)
+			return
+		}
+		walk(data, func(obj map[string]any) {
+			if e, ok := toEvent(obj, pageURL, loc); ok {
+				events = append(events, e)
+			}
+		})
+	})
+	return events, nil
+}
+
+// walk calls fn for every Event object in data, descending into arrays,
+// @graph, subEvent and ItemList elements.
+func walk(data any, fn func(map[string]any)) {
+	switch v := data.(type) {
+	case []any:
+		for _, item := range v {
+			walk(item, fn)
+		}
+	case map[string]any:
+		if isEvent(v) {
+			fn(v)
+			walk(v["subEvent"], fn)
+			walk(v["subEvents"], fn)
+		}
+		walk(v["@graph"], fn)
+		if list, ok := v["itemListElement"]; ok {
+			walk(list, fn)
+		}
+		if item, ok := v["item"]; ok {
+			walk(item, fn)
+		}
+	}
+}
+
+func isEvent(obj map[string]any) bool {
+	for _, t := range types(obj) {
+		if t == "Event" || strings.HasSuffix(t, "Event") || t == "Festival" {
+			return true
+		}
+	}
+	return false
+}
+
+func types(obj map[string]any) []string {
+	var out []string
+	for _, t := range stringList(obj["@type"]) {
+		// "schema:MusicEvent" or "https://schema.org/MusicEvent"
+		if i := strings.LastIndexAny(t, ":/"); i >= 0 {
+			t = t[i+1:]
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+func toEvent(obj map[string]any, pageURL string, loc *time.Location) (model.Event, bool) {
+	e := model.Event{
+		Name:        cleanText(str(obj["name"])),
+		Description: cleanText(stripHTML(str(obj["description"]))),
+		URL:         str(obj["url"]),
+		ImageURL:    image(obj["image"]),
+		Source:      "jsonld",
+	}
+	if e.Name == "" {
+		return e, false
+	}
+
+	e.StartTime = parseDate(str(obj["startDate"]), loc)
+	if e.StartTime.IsZero() {
+		return e, false
+	}
+	e.EndTime = parseDate(str(obj["endDate"]), loc)
+	if e.URL == "" {
+		e.URL = pageURL
+	}
+
+	e.Venue, e.Address = location(obj["location"])
+	e.Price = price(obj["offers"], obj["isAccessibleForFree"])
+
+	switch status := str(obj["eventStatus"]); {
+	case strings.HasSuffix(status, "EventCancelled"):
+		return e, false
+	case strings.HasSuffix(status, "EventPostponed"):
+		e.Tags = append(e.Tags, "postponed")
+	case strings.HasSuffix(status, "EventRescheduled"):
+		e.Tags = append(e.Tags, "rescheduled")
+	case strings.HasSuffix(status, "EventMovedOnline"):
+		e.Tags = append(e.Tags, "online")
+	}
+	e.Tags = append(e.Tags, keywords(obj["keywords"])...)
+
+	e.Category = model.CategoryOther
+	for _, t := range types(obj) {
+		if cat, ok := typeToCategory[t]; ok {
+			e.Category = cat
+			break
+		}
+	}
+	if e.Category == model.CategoryOther {
+		e.Category = model.InferCategory(e.Name, e.Venue)
+	}
+	return e, true
+}
+
+// parseDate handles the ISO 8601 variants seen in the wild.
+func parseDate(s string, loc *time.Location) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05Z0700", "2006-01-02T15:04Z07:00"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.In(loc)
+		}
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// location returns venue name and address from a Place, a string, or a list.
+func location(v any) (venue, address string) {
+	switch l := v.(type) {
+	case string:
+		venue, address, _ = strings.Cut(cleanText(l), ",")
+		return strings.TrimSpace(venue), strings.TrimSpace(address)
+	case []any:
+		for _, item := range l {
+			// Skip VirtualLocation entries.
+			if m, ok := item.(map[string]any); ok && str(m["@type"]) == "VirtualLocation" {
+				continue
+			}
+			if venue, address = location(item); venue != "" || address != "" {
+				return
+			}
+		}
+	case map[string]any:
+		venue = cleanText(str(l["name"]))
+		address = postalAddress(l["address"])
+	}
+	return
+}
+
+func postalAddress(v any) string {
+	switch a := v.(type) {
+	case string:
+		return cleanText(a)
+	case map[string]any:
+		street := cleanText(str(a["streetAddress"]))
+		city := strings.TrimSpace(cleanText(str(a["postalCode"])) + " " + cleanText(str(a["addressLocality"])))
+		switch {
+		case street != "" && city != "":
+			return street + ", " + city
+		case street != "":
+			return street
+		default:
+			return city
+		}
+	}
+	return ""
+}
+
+// price formats offers as "12 €", "10–25 €" or "free".
+func price(offers, free any) string {
+	if b, ok := free.(bool); ok && b {
+		return "free"
+	}
+
+	var list []map[string]any
+	switch o := offers.(type) {
+	case map[string]any:
+		list = append(list, o)
+	case []any:
+		for _, item := range o {
+			if m, ok := item.(map[string]any); ok {
+				list = append(list, m)
+			}
+		}
+	}
+
+	low, high := -1.0, -1.0
+	currency := ""
+	for _, o := range list {
+		for _, key := range []string{"price", "lowPrice", "highPrice"} {
+			p, ok := number(o[key])
+			if !ok {
+				continue
+			}
+			if low < 0 || p < low {
+				low = p
+			}
+			if p > high {
+				high = p
+			}
+		}
+		if c := str(o["priceCurrency"]); c != "" {
+			currency = c
+		}
+		if spec, ok := o["priceSpecification"].(map[string]any); ok {
+			if p, ok := number(spec["price"]); ok {
+				if low < 0 || p < low {
+					low = p
+				}
+				if p > high {
+					high = p
+				}
+			}
+		}
+	}
+
+	if low < 0 {
+		return ""
+	}
+	if high <= 0 {
+		return "free"
+	}
+	sym := currencySymbol(currency)
+	if low == high {
+		return formatAmount(low) + " " + sym
+	}
+	return formatAmount(low) + "–" + formatAmount(high) + " " + sym
+}
+
+func currencySymbol(c string) string {
+	switch strings.ToUpper(c) {
+	case "", "EUR":
+		return "€"
+	default:
+		return strings.ToUpper(c)
+	}
+}
+
+func formatAmount(f float64) string {
+	if f == float64(int(f)) {
+		return fmt.Sprintf("%d", int(f))
+	}
+	return strings.Replace(fmt.Sprintf("%.2f", f), ".", ",", 1)
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case string:
+		n = strings.TrimSpace(strings.Replace(n, ",", ".", 1))
+		var f float64
+		if _, err := fmt.Sscanf(n, "%f", &f); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+func image(v any) string {
+	switch i := v.(type) {
+	case string:
+		return i
+	case []any:
+		for _, item := range i {
+			if u := image(item); u != "" {
+				return u
+			}
+		}
+	case map[string]any:
+		if u := str(i["url"]); u != "" {
+			return u
+		}
+		return str(i["contentUrl"])
+	}
+	return ""
+}
+
+func keywords(v any) []string {
+	var out []string
+	for _, k := range stringList(v) {
+		for _, part := range strings.Split(k, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+// str returns v as a string, taking the first element of arrays and the
+// @id or @value of objects.
+func str(v any) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case []any:
+		if len(s) > 0 {
+			return str(s[0])
+		}
+	case map[string]any:
+		if val := str(s["@value"]); val != "" {
+			return val
+		}
+		return str(s["@id"])
+	}
+	return ""
+}
+
+// stringList returns v as a list of strings.
+func stringList(v any) []string {
+	switch s := v.(type) {
+	case string:
+		return []string{s}
+	case []any:
+		var out []string
+		for _, item := range s {
+			if t, ok := item.(string); ok {
+				out = append(out, t)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func stripHTML(s string) string {
+	if !strings.Contains(s, "<") {
+		return s
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(s))
+	if err != nil {
+		return s
+	}
+	return doc.Text()
+}
+
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,71 @@
+package jsonld
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+func TestFetchFixture(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, loc)
+
+	events, err := New("testdata/venue.html").Fetch(context.Background(), from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name, venue, address, category, price, image string
+		start                                        time.Time
+		tags                                         []string
+	}{
+		{
+			name: "Kettcar – Live 2026", venue: "Täubchenthal", address: "Wachsmuthstraße 1, 04229 Leipzig",
+			category: model.CategoryConcert, price: "34,50–39 €", image: "https://example.org/img/kettcar.jpg",
+			start: time.Date(2026, 3, 6, 20, 0, 0, 0, loc),
+		},
+		{
+			name: "Wave-Gotik-Treffen Warm-up", venue: "Täubchenthal", address: "Wachsmuthstraße 1, 04229 Leipzig",
+			category: model.CategoryCulture, price: "free",
+			start: time.Date(2026, 3, 7, 0, 0, 0, 0, loc), tags: []string{"gothic", "wave"},
+		},
+		{
+			name: "Lesung im Dunkeln", venue: "Kleiner Saal", address: "Wachsmuthstraße 1, 04229 Leipzig",
+			category: model.CategoryTheater, price: "free",
+			start: time.Date(2026, 3, 7, 18, 0, 0, 0, loc),
+		},
+		{
+			name: "Comedy Mixed Show", venue: "Täubchenthal",
+			category: model.CategoryTheater, price: "15–22 €", image: "https://example.org/img/comedy.jpg",
+			start: time.Date(2026, 3, 10, 19, 30, 0, 0, loc), tags: []string{"postponed"},
+		},
+	}
+
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.Name != w.name || e.Venue != w.venue || e.Address != w.address ||
+			e.Category != w.category || e.Price != w.price || e.ImageURL != w.image {
+			t.Errorf("event %d = %+v\nwant %+v", i, e, w)
+		}
+		if !e.StartTime.Equal(w.start) {
+			t.Errorf("event %d start = %v, want %v", i, e.StartTime, w.start)
+		}
+		if len(e.Tags) != len(w.tags) {
+			t.Errorf("event %d tags = %v, want %v", i, e.Tags, w.tags)
+		}
+	}
+
+	if got := events[0].Description; got != "Die Hamburger Band live im Täubchenthal." {
+		t.Errorf("description = %q", got)
+	}
+	if !events[0].EndTime.Equal(time.Date(2026, 3, 6, 23, 0, 0, 0, loc)) {
+		t.Errorf("end = %v", events[0].EndTime)
+	}
+}
@@ -0,0 +1,94 @@
+package leipzigde
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+// FetchDetails reads the event's detail page for the full description,
+// street address, admission, organizer and end time.
+func (s *Source) FetchDetails(ctx context.Context, e model.Event) (source.Details, error) {
+	if !strings.HasPrefix(e.URL, baseURL) {
+		return source.Details{}, source.ErrNoDetails
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", e.URL, nil)
+	if err != nil {
+		return source.Details{}, err
+	}
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return source.Details{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return source.Details{}, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return source.Details{}, err
+	}
+	return parseDetails(doc, e), nil
+}
+
+// parseDetails reads a detail page. Like the list cards, its facts are
+// span.icon-text pairs of a Material icon name and a value.
+func parseDetails(doc *goquery.Document, e model.Event) source.Details {
+	var d source.Details
+
+	main := doc.Find("main").First()
+	if main.Length() == 0 {
+		main = doc.Selection
+	}
+
+	main.Find("span.icon-text").Each(func(_ int, iconText *goquery.Selection) {
+		icon := strings.TrimSpace(iconText.Find("span.icon").First().Text())
+		var lines []string
+		iconText.Find("span").Each(func(_ int, s *goquery.Selection) {
+			if !s.HasClass("icon") {
+				if t := strings.Join(strings.Fields(s.Text()), " "); t != "" {
+					lines = append(lines, t)
+				}
+			}
+		})
+		if len(lines) == 0 {
+			return
+		}
+
+		switch icon {
+		case "event", "schedule":
+			if _, end := parseDateTime(lines[len(lines)-1]); end.After(e.StartTime) {
+				d.EndTime = end
+			}
+		case "location_on":
+			// First line repeats the venue; the rest is the street address.
+			if len(lines) > 1 && strings.EqualFold(lines[0], e.Venue) {
+				lines = lines[1:]
+			}
+			d.Address = strings.Join(lines, ", ")
+		case "euro", "euro_symbol", "payments", "sell":
+			d.Price = strings.Join(lines, " ")
+		case "person", "groups", "corporate_fare", "apartment":
+			d.Organizer = lines[0]
+		}
+	})
+
+	for _, sel := range []string{".event-detail__description", ".ce-bodytext", "article .text", "article .rte"} {
+		text := strings.TrimSpace(main.Find(sel).First().Text())
+		if text != "" {
+			d.Description = strings.Join(strings.Fields(text), " ")
+			break
+		}
+	}
+	return d
+}
@@ -0,0 +1,47 @@
+package leipzigde
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+func TestParseDetails(t *testing.T) {
+	f, err := os.Open("testdata/detail.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	e := model.Event{Name: "Moop Mama", Venue: "Conne Island", StartTime: time.Date(2026, 10, 16, 20, 0, 0, 0, loc)}
+	want := source.Details{
+		Description: "Die Münchner Brass-Band kommt mit neuem Album.",
+		Address:     "Koburger Straße 3, 04277 Leipzig",
+		Price:       "VVK 25 € AK 29 €",
+		Organizer:   "Conne Island e.V.",
+		EndTime:     time.Date(2026, 10, 16, 23, 0, 0, 0, loc),
+	}
+	got := parseDetails(doc, e)
+	if got.Description != want.Description || got.Address != want.Address || got.Price != want.Price ||
+		got.Organizer != want.Organizer || !got.EndTime.Equal(want.EndTime) {
+		t.Errorf("parseDetails =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestFetchDetailsOtherSite(t *testing.T) {
+	_, err := New().FetchDetails(context.Background(), model.Event{URL: "https://prinz.de/leipzig/events/x"})
+	if !errors.Is(err, source.ErrNoDetails) {
+		t.Errorf("err = %v, want ErrNoDetails", err)
+	}
+}
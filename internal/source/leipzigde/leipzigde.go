@@ -4,99 +4,148 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/havocked/leipzig-cli/internal/httpx"
 	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
 )
 
 const baseURL = "https://www.leipzig.de"
 
 var topicToCategory = map[string]string{
-	"Konzert":            model.CategoryConcert,
-	"Klassik":            model.CategoryConcert,
-	"Jazz und Blues":     model.CategoryConcert,
-	"Kabarett":           model.CategoryTheater,
-	"Oper und Operette":  model.CategoryTheater,
-	"Führungen":          model.CategoryCulture,
-	"Kurse und Treffs":   model.CategoryCulture,
-	"Mitmach-Angebot":    model.CategoryCulture,
-	"Beratung":           model.CategoryOther,
-	"Ausstellungen":      model.CategoryExhibition,
-	"Lesung":             model.CategoryCulture,
+	"Konzert":              model.CategoryConcert,
+	"Klassik":              model.CategoryConcert,
+	"Jazz und Blues":       model.CategoryConcert,
+	"Kabarett":             model.CategoryTheater,
+	"Oper und Operette":    model.CategoryTheater,
+	"Führungen":            model.CategoryCulture,
+	"Kurse und Treffs":     model.CategoryCulture,
+	"Mitmach-Angebot":      model.CategoryCulture,
+	"Beratung":             model.CategoryOther,
+	"Ausstellungen":        model.CategoryExhibition,
+	"Lesung":               model.CategoryCulture,
 	"Kinder & Jugendliche": model.CategoryFamily,
-	"Freizeit":           model.CategoryOther,
-	"Bühne":              model.CategoryTheater,
-	"Sport":              model.CategorySport,
-	"Märkte":             model.CategoryMarket,
+	"Freizeit":             model.CategoryOther,
+	"Bühne":                model.CategoryTheater,
+	"Sport":                model.CategorySport,
+	"Märkte":               model.CategoryMarket,
 }
 
 type Source struct {
 	client *http.Client
+	topics []string
+}
+
+// Options configures the adapter.
+type Options struct {
+	// Client is used for all requests; nil means httpx.Default().
+	Client *http.Client
+	// Topics restricts results server-side to these calendar topics
+	// (e.g. "Konzert", "Ausstellungen"). Empty means all topics.
+	Topics []string
 }
 
 func New() *Source {
-	return &Source{client: &http.Client{Timeout: 30 * time.Second}}
+	return NewWithOptions(Options{})
+}
+
+func NewWithOptions(opts Options) *Source {
+	client := opts.Client
+	if client == nil {
+		client = httpx.Default()
+	}
+	return &Source{client: client, topics: opts.Topics}
+}
+
+func init() {
+	source.Register("leipzig.de", func(cfg source.Config) (source.Source, error) {
+		return NewWithOptions(Options{Topics: cfg.List("topics")}), nil
+	})
 }
 
 func (s *Source) ID() string { return "leipzig.de" }
 
-func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
-	urls := pickURLs(from, to)
+func (s *Source) Describe() source.Description {
+	return source.Description{
+		Text:       "City of Leipzig official event calendar",
+		Homepage:   eventsBase,
+		Categories: source.MappedCategories(topicToCategory),
+	}
+}
 
+func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
 	var allEvents []model.Event
 	seen := make(map[string]bool)
+	totalPages := 0
 
-	for i, u := range urls {
-		if i > 0 {
-			time.Sleep(500 * time.Millisecond)
-		}
-
-		events, err := s.fetchPage(ctx, u)
+	for page := 1; page <= maxPages; page++ {
+		u := calendarURL(from, to, s.topics, page)
+		events, info, err := s.fetchPage(ctx, u)
 		if err != nil {
 			return nil, fmt.Errorf("fetch %s: %w", u, err)
 		}
+		if page == 1 && info.total > 0 && info.pageSize > 0 {
+			totalPages = (info.total + info.pageSize - 1) / info.pageSize
+		}
 
 		for _, e := range events {
 			key := e.Name + "|" + e.StartTime.String() + "|" + e.Venue
 			if !seen[key] {
 				seen[key] = true
-				if !from.IsZero() && e.StartTime.Before(from) {
-					continue
-				}
-				if !to.IsZero() && e.StartTime.After(to) {
+				if !e.Overlaps(from, to) {
 					continue
 				}
 				allEvents = append(allEvents, e)
 			}
 		}
+
+		// Stop at the last page: prefer the result count, fall back to the
+		// pager's "next" link, and always stop on an empty page.
+		switch {
+		case len(events) == 0:
+			return allEvents, nil
+		case totalPages > 0 && page >= totalPages:
+			return allEvents, nil
+		case totalPages == 0 && !info.hasNext:
+			return allEvents, nil
+		}
 	}
 
 	return allEvents, nil
 }
 
-func (s *Source) fetchPage(ctx context.Context, url string) ([]model.Event, error) {
+// pageInfo is the pagination state read from a result page.
+type pageInfo struct {
+	total    int  // total results, from "1 - 25 von 320 Ergebnissen"
+	pageSize int  // results on a full page
+	hasNext  bool // pager has a "next" link
+}
+
+func (s *Source) fetchPage(ctx context.Context, url string) ([]model.Event, pageInfo, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, err
+		return nil, pageInfo{}, err
 	}
-	req.Header.Set("User-Agent", "leipzig-cli/1.0")
 	req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
 
 	resp, err := s.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, pageInfo{}, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+		return nil, pageInfo{}, fmt.Errorf("HTTP %d", resp.StatusCode)
 	}
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, pageInfo{}, err
 	}
 
 	var events []model.Event
@@ -158,7 +207,31 @@ func (s *Source) fetchPage(ctx context.Context, url string) ([]model.Event, erro
 		}
 	})
 
-	return events, nil
+	info := parsePageInfo(doc)
+	if len(events) == 0 && info.total > 0 {
+		return nil, info, fmt.Errorf("page reports %d results but no event cards matched (markup changed?)", info.total)
+	}
+	return events, info, nil
+}
+
+func parsePageInfo(doc *goquery.Document) pageInfo {
+	var info pageInfo
+
+	// "1 - 25 von 320 Ergebnissen": first <strong> is the range, second the total
+	doc.Find(".result-info strong").Each(func(i int, s *goquery.Selection) {
+		switch i {
+		case 0:
+			var first, last int
+			if n, _ := fmt.Sscanf(strings.ReplaceAll(s.Text(), " ", ""), "%d-%d", &first, &last); n == 2 {
+				info.pageSize = last - first + 1
+			}
+		case 1:
+			fmt.Sscanf(s.Text(), "%d", &info.total)
+		}
+	})
+
+	info.hasNext = doc.Find(`.pagination a[rel="next"], .pagination li.next a, a.page-link[aria-label*="chste"]`).Length() > 0
+	return info
 }
 
 func parseDateTime(raw string) (start, end time.Time) {
@@ -225,37 +298,30 @@ func mapTopicToCategory(topic string) string {
 
 const eventsBase = baseURL + "/kultur-und-freizeit/veranstaltungen/"
 
-func pickURLs(from, to time.Time) []string {
+// maxPages bounds pagination in case the pager never runs out.
+const maxPages = 40
+
+// calendarURL builds the calendar search URL for [from, to]. The calendar
+// filters by whole days, so times are dropped here and applied in Fetch.
+func calendarURL(from, to time.Time, topics []string, page int) string {
 	loc, _ := time.LoadLocation("Europe/Berlin")
-	now := time.Now().In(loc)
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
-	tomorrow := today.Add(24 * time.Hour)
-	duration := to.Sub(from)
-
-	// "week" — fetch today + tomorrow + weekend to cover as much as possible
-	if duration > 3*24*time.Hour {
-		return []string{
-			eventsBase + "termine-heute",
-			eventsBase + "termine-morgen",
-			eventsBase + "termine-dieses-wochenende",
-		}
+	if from.IsZero() {
+		from = time.Now()
 	}
-
-	// "weekend"
-	if duration > 24*time.Hour {
-		urls := []string{eventsBase + "termine-dieses-wochenende"}
-		// If today is part of the weekend, also fetch today
-		if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
-			urls = append([]string{eventsBase + "termine-heute"}, urls...)
-		}
-		return urls
+	if to.IsZero() || !to.After(from) {
+		to = from.Add(time.Second)
 	}
 
-	// "tomorrow"
-	if from.Year() == tomorrow.Year() && from.Month() == tomorrow.Month() && from.Day() == tomorrow.Day() {
-		return []string{eventsBase + "termine-morgen"}
+	params := url.Values{}
+	params.Set("tx_leevents[filtered]", "1")
+	params.Set("tx_leevents[filter][startDate]", from.In(loc).Format("02.01.2006"))
+	// to is exclusive at midnight; don't ask for the following day.
+	params.Set("tx_leevents[filter][endDate]", to.Add(-time.Second).In(loc).Format("02.01.2006"))
+	for _, t := range topics {
+		params.Add("tx_leevents[filter][topic][]", t)
 	}
-
-	// Default: "today"
-	return []string{eventsBase + "termine-heute"}
+	if page > 1 {
+		params.Set("tx_leevents[filter][page]", strconv.Itoa(page))
+	}
+	return eventsBase + "?" + params.Encode()
 }
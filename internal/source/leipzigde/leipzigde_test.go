@@ -0,0 +1,45 @@
+package leipzigde
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/havocked/leipzig-cli/internal/golden"
+	"github.com/havocked/leipzig-cli/internal/httpx"
+)
+
+// Fixtures are refreshed with "leipzig dev record leipzig.de".
+const fixtures = "testdata/http"
+
+func TestFetchGolden(t *testing.T) {
+	rec, err := httpx.LoadRecording(fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := NewWithOptions(Options{Client: httpx.Replay(fixtures).Client()})
+
+	events, err := src.Fetch(context.Background(), rec.From, rec.To)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) == 0 {
+		t.Fatal("no events parsed from recorded pages")
+	}
+	golden.AssertJSON(t, "testdata/fetch.golden.json", events)
+}
+
+func TestFetchPageMarkupChanged(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `<p class="result-info"><strong>1 - 25</strong> von <strong>120</strong> Ergebnissen</p>
+<ul><li data-event="1"><div class="new-card"><h3>Renamed markup</h3></div></li></ul>`)
+	}))
+	defer srv.Close()
+
+	src := NewWithOptions(Options{Client: srv.Client()})
+	if _, _, err := src.fetchPage(context.Background(), srv.URL); err == nil {
+		t.Error("expected an error when no cards match but results are reported")
+	}
+}
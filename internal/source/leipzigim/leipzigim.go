@@ -0,0 +1,290 @@
+package leipzigim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/havocked/leipzig-cli/internal/httpx"
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+const (
+	baseURL = "https://www.leipzig-im.de/"
+	listURL = baseURL + "index.php?section=anzeigensort&sort=rubrik"
+)
+
+// rubricToCategory maps leipzig-im.de rubrics to canonical categories.
+var rubricToCategory = map[string]string{
+	"konzert":          model.CategoryConcert,
+	"konzerte":         model.CategoryConcert,
+	"musik":            model.CategoryConcert,
+	"kinder & familie": model.CategoryFamily,
+	"kinder":           model.CategoryFamily,
+	"familie":          model.CategoryFamily,
+	"theater":          model.CategoryTheater,
+	"kabarett":         model.CategoryTheater,
+	"bühne":            model.CategoryTheater,
+	"ausstellungen":    model.CategoryExhibition,
+	"ausstellung":      model.CategoryExhibition,
+	"sport":            model.CategorySport,
+	"märkte":           model.CategoryMarket,
+	"markt":            model.CategoryMarket,
+	"party":            model.CategoryNightlife,
+	"disco & party":    model.CategoryNightlife,
+	"club":             model.CategoryNightlife,
+	"lesung":           model.CategoryCulture,
+	"lesungen":         model.CategoryCulture,
+	"kino":             model.CategoryCulture,
+	"vortrag":          model.CategoryCulture,
+	"führungen":        model.CategoryCulture,
+}
+
+type Source struct {
+	client *http.Client
+}
+
+func New() *Source {
+	return &Source{client: httpx.Default()}
+}
+
+func init() {
+	source.Register("leipzig-im.de", func(source.Config) (source.Source, error) { return New(), nil })
+}
+
+func (s *Source) ID() string { return "leipzig-im.de" }
+
+func (s *Source) Describe() source.Description {
+	return source.Description{
+		Text:       "leipzig-im.de indie venue and scene calendar",
+		Homepage:   baseURL,
+		Categories: source.MappedCategories(rubricToCategory),
+	}
+}
+
+func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", listURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("leipzigim: create request: %w", err)
+	}
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("leipzigim: fetch %s: %w", listURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("leipzigim: %s returned %d", listURL, resp.StatusCode)
+	}
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	events, err := parse(resp.Body, from, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []model.Event
+	for _, e := range events {
+		if !e.Overlaps(from, to) {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// parse reads the rubric-sorted listing. Entries without a year in their
+// date are placed in the first year that doesn't put them before ref.
+func parse(r io.Reader, ref time.Time, loc *time.Location) ([]model.Event, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("leipzigim: parse HTML: %w", err)
+	}
+	if ref.IsZero() {
+		ref = time.Now().In(loc)
+	}
+
+	var events []model.Event
+
+	doc.Find("div.rubrik").Each(func(_ int, rubric *goquery.Selection) {
+		rubricName := strings.TrimSpace(rubric.Find(".rubrik-titel").First().Text())
+		category := mapRubric(rubricName)
+
+		rubric.Find("div.termin").Each(func(_ int, entry *goquery.Selection) {
+			titleEl := entry.Find(".titel a").First()
+			name := strings.TrimSpace(titleEl.Text())
+			if name == "" {
+				name = strings.TrimSpace(entry.Find(".titel").First().Text())
+			}
+			if name == "" {
+				return
+			}
+
+			date := parseDate(entry.Find(".datum").First().Text(), ref, loc)
+			if date.IsZero() {
+				return
+			}
+			start, end := parseTimes(date, entry.Find(".zeit").First().Text(), loc)
+
+			venue := cleanText(entry.Find(".ort").First().Text())
+
+			e := model.Event{
+				Name:        name,
+				Description: cleanText(entry.Find(".text").First().Text()),
+				StartTime:   start,
+				EndTime:     end,
+				Venue:       venue,
+				Category:    category,
+				Price:       cleanText(entry.Find(".preis").First().Text()),
+				Source:      "leipzig-im.de",
+			}
+
+			if href, ok := titleEl.Attr("href"); ok {
+				e.URL = absURL(href)
+			}
+			if src, ok := entry.Find("img").First().Attr("src"); ok {
+				e.ImageURL = absURL(src)
+			}
+
+			if e.Category == model.CategoryOther {
+				e.Category = model.InferCategory(e.Name, e.Venue)
+			}
+
+			events = append(events, e)
+		})
+	})
+
+	return events, nil
+}
+
+func mapRubric(rubric string) string {
+	r := strings.ToLower(strings.TrimSpace(rubric))
+	if cat, ok := rubricToCategory[r]; ok {
+		return cat
+	}
+	// Compound rubrics like "Konzert / Jazz"
+	for _, part := range strings.FieldsFunc(r, func(c rune) bool { return c == '/' || c == ',' }) {
+		if cat, ok := rubricToCategory[strings.TrimSpace(part)]; ok {
+			return cat
+		}
+	}
+	return model.CategoryOther
+}
+
+var dateRe = regexp.MustCompile(`(\d{1,2})\.(\d{1,2})\.(\d{2,4})?`)
+
+// parseDate handles "Sa, 22.02.2026", "Sa. 22.02.26" and "22.02.".
+func parseDate(text string, ref time.Time, loc *time.Location) time.Time {
+	m := dateRe.FindStringSubmatch(text)
+	if m == nil {
+		return time.Time{}
+	}
+	day, _ := strconv.Atoi(m[1])
+	month, _ := strconv.Atoi(m[2])
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}
+	}
+
+	if m[3] != "" {
+		year, _ := strconv.Atoi(m[3])
+		if year < 100 {
+			year += 2000
+		}
+		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+	}
+
+	// No year: assume the next occurrence on or after ref's date. Listings
+	// that lag a few days behind still land in the current year.
+	ref = ref.In(loc)
+	t := time.Date(ref.Year(), time.Month(month), day, 0, 0, 0, 0, loc)
+	if t.Before(ref.AddDate(0, 0, -7)) {
+		t = t.AddDate(1, 0, 0)
+	}
+	return t
+}
+
+var clockRe = regexp.MustCompile(`(\d{1,2})(?:[:.](\d{2}))?\s*(?:Uhr|h\b)`)
+
+// parseTimes reads times like "20 Uhr", "20:00 Uhr", "19.30 Uhr",
+// "20–23 Uhr", "Einlass 19 Uhr, Beginn 20 Uhr" or "ganztägig".
+func parseTimes(date time.Time, text string, loc *time.Location) (start, end time.Time) {
+	text = strings.TrimSpace(text)
+	start = date
+	if text == "" || strings.Contains(strings.ToLower(text), "ganztägig") {
+		return
+	}
+
+	// "Beginn" wins over "Einlass" when both are listed.
+	if i := strings.Index(strings.ToLower(text), "beginn"); i >= 0 {
+		text = text[i:]
+	}
+
+	// Ranges: "20–23 Uhr", "20:00 - 23:00 Uhr", "20 bis 23 Uhr"
+	for _, sep := range []string{"–", "-", " bis "} {
+		if parts := strings.SplitN(text, sep, 2); len(parts) == 2 {
+			h1, m1, ok1 := parseClock(parts[0])
+			h2, m2, ok2 := parseClock(parts[1])
+			if ok1 && ok2 {
+				start = atClock(date, h1, m1, loc)
+				end = atClock(date, h2, m2, loc)
+				if end.Before(start) {
+					end = end.AddDate(0, 0, 1)
+				}
+				return
+			}
+		}
+	}
+
+	if m := clockRe.FindStringSubmatch(text); m != nil {
+		h, _ := strconv.Atoi(m[1])
+		min, _ := strconv.Atoi(m[2])
+		if h < 24 && min < 60 {
+			start = atClock(date, h, min, loc)
+		}
+	}
+	return
+}
+
+var bareClockRe = regexp.MustCompile(`(\d{1,2})(?:[:.](\d{2}))?`)
+
+func parseClock(s string) (h, m int, ok bool) {
+	match := bareClockRe.FindStringSubmatch(s)
+	if match == nil {
+		return 0, 0, false
+	}
+	h, _ = strconv.Atoi(match[1])
+	m, _ = strconv.Atoi(match[2])
+	if h > 23 || m > 59 {
+		return 0, 0, false
+	}
+	return h, m, true
+}
+
+func atClock(date time.Time, h, m int, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), h, m, 0, 0, loc)
+}
+
+func cleanText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func absURL(href string) string {
+	href = strings.TrimSpace(href)
+	switch {
+	case href == "":
+		return ""
+	case strings.HasPrefix(href, "http"):
+		return href
+	default:
+		return baseURL + strings.TrimPrefix(href, "/")
+	}
+}
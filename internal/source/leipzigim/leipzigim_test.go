@@ -0,0 +1,112 @@
+package leipzigim
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+func TestParseFixture(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	f, err := os.Open("testdata/anzeigen.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	ref := time.Date(2026, 2, 20, 0, 0, 0, 0, loc)
+	events, err := parse(f, ref, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name, venue, category, price, url string
+		start, end                        time.Time
+	}{
+		{
+			name: "Jinjer – European Duél Tour", venue: "Felsenkeller", category: model.CategoryConcert,
+			price: "VVK 32 €", url: "https://www.leipzig-im.de/index.php?section=anzeige&id=4711",
+			start: time.Date(2026, 2, 21, 20, 0, 0, 0, loc),
+		},
+		{
+			name: "Tiny Desk Abend", venue: "UT Connewitz", category: model.CategoryConcert,
+			url:   "https://www.leipzig-im.de/index.php?section=anzeige&id=4712",
+			start: time.Date(2026, 2, 22, 19, 30, 0, 0, loc),
+		},
+		{
+			name: "Electric Weekender", venue: "Conne Island", category: model.CategoryNightlife,
+			price: "AK 12 €", url: "https://www.leipzig-im.de/index.php?section=anzeige&id=4713",
+			start: time.Date(2026, 2, 21, 23, 0, 0, 0, loc),
+			end:   time.Date(2026, 2, 22, 6, 0, 0, 0, loc),
+		},
+		{
+			name: "Flohmarkt im Hof", venue: "Werk 2", category: model.CategoryMarket,
+			url:   "https://www.leipzig-im.de/index.php?section=anzeige&id=4714",
+			start: time.Date(2026, 2, 21, 0, 0, 0, 0, loc),
+		},
+	}
+
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.Name != w.name || e.Venue != w.venue || e.Category != w.category || e.Price != w.price || e.URL != w.url {
+			t.Errorf("event %d = %+v, want %+v", i, e, w)
+		}
+		if !e.StartTime.Equal(w.start) {
+			t.Errorf("event %d start = %v, want %v", i, e.StartTime, w.start)
+		}
+		if !e.EndTime.Equal(w.end) {
+			t.Errorf("event %d end = %v, want %v", i, e.EndTime, w.end)
+		}
+		if e.Source != "leipzig-im.de" {
+			t.Errorf("event %d source = %q", i, e.Source)
+		}
+	}
+	if events[0].ImageURL != "https://www.leipzig-im.de/bilder/4711.jpg" {
+		t.Errorf("image = %q", events[0].ImageURL)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	ref := time.Date(2026, 12, 28, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"Sa, 02.01.2027", time.Date(2027, 1, 2, 0, 0, 0, 0, loc)},
+		{"Sa. 02.01.27", time.Date(2027, 1, 2, 0, 0, 0, 0, loc)},
+		{"Sa, 02.01.", time.Date(2027, 1, 2, 0, 0, 0, 0, loc)},
+		{"Mo, 28.12.", time.Date(2026, 12, 28, 0, 0, 0, 0, loc)},
+		{"morgen", time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := parseDate(tt.in, ref, loc); !got.Equal(tt.want) {
+			t.Errorf("parseDate(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMapRubric(t *testing.T) {
+	tests := map[string]string{
+		"Konzert":          model.CategoryConcert,
+		"Kinder & Familie": model.CategoryFamily,
+		"Theater":          model.CategoryTheater,
+		"Ausstellungen":    model.CategoryExhibition,
+		"Sport":            model.CategorySport,
+		"Märkte":           model.CategoryMarket,
+		"Konzert / Jazz":   model.CategoryConcert,
+		"Irgendwas":        model.CategoryOther,
+	}
+	for in, want := range tests {
+		if got := mapRubric(in); got != want {
+			t.Errorf("mapRubric(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
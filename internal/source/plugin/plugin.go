@@ -0,0 +1,202 @@
+// Package plugin runs external executables named leipzig-source-<id> as
+// event sources, so scrapers can be written in any language.
+//
+// Protocol: the executable receives a Request as JSON on stdin and writes a
+// JSON array of model.Event to stdout, then exits 0. Anything on stderr is
+// included in the error if it fails. Called with the single argument
+// --describe, it may print a Description as JSON; that is optional.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+// Prefix is the executable name prefix plugins are discovered by.
+const Prefix = "leipzig-source-"
+
+const (
+	maxOutput       = 32 << 20
+	describeTimeout = 3 * time.Second
+)
+
+// Request is written to the plugin's stdin.
+type Request struct {
+	From    time.Time         `json:"from"`
+	To      time.Time         `json:"to"`
+	URLs    []string          `json:"urls,omitempty"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// Description is what a plugin prints for --describe.
+type Description struct {
+	Description string   `json:"description"`
+	Homepage    string   `json:"homepage,omitempty"`
+	HorizonDays int      `json:"horizonDays,omitempty"`
+	Categories  []string `json:"categories,omitempty"`
+}
+
+type Source struct {
+	id   string
+	path string
+	cfg  source.Config
+
+	descOnce sync.Once
+	desc     source.Description
+}
+
+// New returns a source running the executable at path.
+func New(id, path string, cfg source.Config) *Source {
+	return &Source{id: id, path: path, cfg: cfg}
+}
+
+// Discover finds plugins on $PATH. Like the shell, the first executable
+// with a given name wins.
+func Discover() map[string]string {
+	found := make(map[string]string)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			dir = "."
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if !strings.HasPrefix(name, Prefix) {
+				continue
+			}
+			id := strings.TrimPrefix(name, Prefix)
+			if runtime.GOOS == "windows" {
+				id = strings.TrimSuffix(id, filepath.Ext(id))
+			}
+			if id == "" || found[id] != "" {
+				continue
+			}
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err != nil || info.IsDir() || !executable(info) {
+				continue
+			}
+			found[id] = path
+		}
+	}
+	return found
+}
+
+func executable(info os.FileInfo) bool {
+	return runtime.GOOS == "windows" || info.Mode()&0o111 != 0
+}
+
+func (s *Source) ID() string { return s.id }
+
+// Path returns the plugin executable.
+func (s *Source) Path() string { return s.path }
+
+func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
+	req, err := json.Marshal(Request{From: from, To: to, URLs: s.cfg.URLs, Options: s.cfg.Options})
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.run(ctx, bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	events, err := decodeEvents(out)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", s.id, err)
+	}
+	for i := range events {
+		events[i].Source = s.id
+		events[i].ID, events[i].Sources, events[i].FieldSources = "", nil, nil
+		if events[i].Category == "" || events[i].Category == model.CategoryOther {
+			events[i].Category = model.InferCategory(events[i].Name, events[i].Venue)
+		}
+	}
+	return events, nil
+}
+
+// Describe asks the plugin for its description, falling back to its path.
+func (s *Source) Describe() source.Description {
+	s.descOnce.Do(func() { s.desc = s.describe() })
+	return s.desc
+}
+
+func (s *Source) describe() source.Description {
+	d := source.Description{Text: "external plugin " + s.path}
+
+	ctx, cancel := context.WithTimeout(context.Background(), describeTimeout)
+	defer cancel()
+	if out, err := s.run(ctx, nil, "--describe"); err == nil {
+		var pd Description
+		if json.Unmarshal(out, &pd) == nil && pd.Description != "" {
+			d = source.Description{
+				Text:       pd.Description + " (plugin)",
+				Homepage:   pd.Homepage,
+				Horizon:    time.Duration(pd.HorizonDays) * 24 * time.Hour,
+				Categories: pd.Categories,
+			}
+		}
+	}
+	return d
+}
+
+// run executes the plugin. The context's deadline (set by the engine's
+// source timeout) kills it.
+func (s *Source) run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, s.path, args...)
+	cmd.Stdin = stdin
+	var stdout limitedBuffer
+	stdout.limit = maxOutput
+	var stderr limitedBuffer
+	stderr.limit = 4 << 10
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	switch {
+	case ctx.Err() != nil:
+		return nil, fmt.Errorf("plugin %s: %w", s.id, ctx.Err())
+	case stdout.overflow:
+		return nil, fmt.Errorf("plugin %s: output exceeds %d bytes", s.id, maxOutput)
+	case err != nil:
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			return nil, fmt.Errorf("plugin %s: %w: %s", s.id, err, msg)
+		}
+		return nil, fmt.Errorf("plugin %s: %w", s.id, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// limitedBuffer keeps the first limit bytes written to it.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); len(p) > room {
+		b.overflow = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
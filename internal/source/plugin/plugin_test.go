@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+// writePlugin creates an executable shell script leipzig-source-<id> in dir.
+func writePlugin(t *testing.T, dir, id, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, Prefix+id)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func skipWithoutShell(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+}
+
+const demo = `
+if [ "$1" = "--describe" ]; then
+  echo '{"description": "Demo venue", "horizonDays": 30, "categories": ["concert"]}'
+  exit 0
+fi
+input=$(cat)
+case "$input" in *'"from":"2026-10-16T00:00:00+02:00"'*) ;; *) echo "bad request: $input" >&2; exit 1 ;; esac
+echo '[{"name": "Jazz am Abend", "startTime": "2026-10-16T20:00:00+02:00", "venue": "Horns Erben", "category": "concert", "source": "ignored"},
+       {"name": "Flohmarkt im Hof", "startTime": "2026-10-16T10:00:00+02:00", "venue": "Werk 2"}]'
+`
+
+func TestDiscoverAndFetch(t *testing.T) {
+	skipWithoutShell(t)
+	dir := t.TempDir()
+	writePlugin(t, dir, "demo", demo)
+	os.WriteFile(filepath.Join(dir, Prefix+"noexec"), []byte("#!/bin/sh\n"), 0o644)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	found := Discover()
+	if len(found) != 1 || found["demo"] == "" {
+		t.Fatalf("Discover() = %v, want only demo", found)
+	}
+
+	src := New("demo", found["demo"], source.Config{})
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	from := time.Date(2026, 10, 16, 0, 0, 0, 0, loc)
+	events, err := src.Fetch(context.Background(), from, from.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if events[0].Source != "demo" {
+		t.Errorf("Source = %q, want plugin ID", events[0].Source)
+	}
+	if events[1].Category != "market" {
+		t.Errorf("inferred category = %q, want market", events[1].Category)
+	}
+
+	d := src.Describe()
+	if d.Text != "Demo venue (plugin)" || d.Horizon != 30*24*time.Hour {
+		t.Errorf("Describe() = %+v", d)
+	}
+}
+
+func TestFetchRejectsInvalidOutput(t *testing.T) {
+	skipWithoutShell(t)
+	dir := t.TempDir()
+	for name, tc := range map[string]struct{ script, want string }{
+		"garbage":      {`echo 'not json'`, "invalid output"},
+		"unknownField": {`echo '[{"name": "X", "startTime": "2026-10-16T20:00:00Z", "title": "X"}]'`, "unknown field"},
+		"noStart":      {`echo '[{"name": "X"}]'`, "event 0: missing startTime"},
+		"badCategory":  {`echo '[{"name": "X", "startTime": "2026-10-16T20:00:00Z", "category": "jazz"}]'`, `unknown category "jazz"`},
+		"exit1":        {`echo 'site down' >&2; exit 1`, "site down"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			src := New(name, writePlugin(t, dir, name, "cat >/dev/null\n"+tc.script), source.Config{})
+			_, err := src.Fetch(context.Background(), time.Now(), time.Now().Add(time.Hour))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetchTimeout(t *testing.T) {
+	skipWithoutShell(t)
+	src := New("slow", writePlugin(t, t.TempDir(), "slow", "sleep 10\n"), source.Config{})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := src.Fetch(ctx, time.Now(), time.Now().Add(time.Hour))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Fetch took %v after the deadline", elapsed)
+	}
+}
@@ -0,0 +1,52 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+// decodeEvents parses and validates plugin output: a JSON array of events
+// using model.Event's field names, each with a name and a start time.
+func decodeEvents(data []byte) ([]model.Event, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var events []model.Event
+	if err := dec.Decode(&events); err != nil {
+		return nil, fmt.Errorf("invalid output: %w", err)
+	}
+	if dec.More() {
+		return nil, errors.New("invalid output: trailing data after the event array")
+	}
+
+	var errs []error
+	for i, e := range events {
+		if err := validate(e); err != nil {
+			errs = append(errs, fmt.Errorf("event %d: %w", i, err))
+			if len(errs) == 5 {
+				break
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return events, nil
+}
+
+func validate(e model.Event) error {
+	switch {
+	case e.Name == "":
+		return errors.New("missing name")
+	case e.StartTime.IsZero():
+		return errors.New("missing startTime")
+	case !e.EndTime.IsZero() && e.EndTime.Before(e.StartTime):
+		return errors.New("endTime before startTime")
+	case e.Category != "" && !model.ValidCategory(e.Category):
+		return fmt.Errorf("unknown category %q", e.Category)
+	}
+	return nil
+}
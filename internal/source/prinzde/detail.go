@@ -0,0 +1,70 @@
+package prinzde
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+// FetchDetails reads the event's detail page for the full description,
+// address, price, organizer and end time.
+func (s *Source) FetchDetails(ctx context.Context, e model.Event) (source.Details, error) {
+	if !strings.HasPrefix(e.URL, "https://prinz.de/") {
+		return source.Details{}, source.ErrNoDetails
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", e.URL, nil)
+	if err != nil {
+		return source.Details{}, fmt.Errorf("prinzde: create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return source.Details{}, fmt.Errorf("prinzde: fetch %s: %w", e.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return source.Details{}, fmt.Errorf("prinzde: %s returned %d", e.URL, resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return source.Details{}, fmt.Errorf("prinzde: parse HTML: %w", err)
+	}
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	return parseDetails(doc, e, loc), nil
+}
+
+func parseDetails(doc *goquery.Document, e model.Event, loc *time.Location) source.Details {
+	text := func(sel string) string {
+		return strings.Join(strings.Fields(doc.Find(sel).First().Text()), " ")
+	}
+
+	d := source.Details{
+		Description: text(".event-detail-description, .event-description"),
+		Address:     text(".event-location-address, .event-detail-location address"),
+		Price:       text(".event-price, .event-detail-price"),
+		Organizer:   text(".event-organizer, .event-detail-organizer"),
+	}
+
+	// "20:00 - 23:00 Uhr"
+	timeText := strings.ReplaceAll(text(".event-detail-time, .event-time"), "Uhr", "")
+	if _, endText, ok := strings.Cut(timeText, "-"); ok && !e.StartTime.IsZero() {
+		date := time.Date(e.StartTime.Year(), e.StartTime.Month(), e.StartTime.Day(), 0, 0, 0, 0, loc)
+		if end := applyTime(date, endText, loc); !end.Equal(date) {
+			if end.Before(e.StartTime) {
+				end = end.AddDate(0, 0, 1)
+			}
+			d.EndTime = end
+		}
+	}
+	return d
+}
@@ -0,0 +1,52 @@
+package prinzde
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+func TestFetchDetails(t *testing.T) {
+	page, err := os.ReadFile("testdata/detail.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const url = "https://prinz.de/leipzig/events/moop-mama"
+	var requested []string
+	src := NewWithOptions(Options{Client: fakeSite(map[string]string{url: string(page)}, &requested)})
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	e := model.Event{Name: "Moop Mama", URL: url, StartTime: time.Date(2026, 10, 16, 20, 0, 0, 0, loc)}
+	got, err := src.FetchDetails(context.Background(), e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := source.Details{
+		Description: "Brass-Rap aus München. Support: tba",
+		Address:     "Koburger Str. 3, 04277 Leipzig",
+		Price:       "25,00 €",
+		Organizer:   "Conne Island e.V.",
+		// Past midnight, so on the next day.
+		EndTime: time.Date(2026, 10, 17, 1, 0, 0, 0, loc),
+	}
+	if got.Description != want.Description || got.Address != want.Address || got.Price != want.Price ||
+		got.Organizer != want.Organizer || !got.EndTime.Equal(want.EndTime) {
+		t.Errorf("FetchDetails =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if _, err := src.FetchDetails(context.Background(), model.Event{URL: "https://prinz.de/leipzig/events/gone"}); err == nil {
+		t.Error("expected error for 404")
+	}
+}
+
+func TestFetchDetailsOtherSite(t *testing.T) {
+	_, err := New().FetchDetails(context.Background(), model.Event{URL: "https://www.leipzig.de/x"})
+	if !errors.Is(err, source.ErrNoDetails) {
+		t.Errorf("err = %v, want ErrNoDetails", err)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/havocked/leipzig-cli/internal/httpx"
 	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
 )
 
 const baseURL = "https://prinz.de/leipzig/events/"
@@ -25,37 +28,165 @@ var categoryTextMap = map[string]string{
 	"ESSEN & TRINKEN":      model.CategoryOther,
 }
 
-type Source struct{}
+// categorySlugs are prinz.de's per-category listing paths.
+var categorySlugs = []string{
+	"konzerte", "buehne", "ausstellungen", "kinder-familie", "sport",
+	"fuehrungen", "stadtleben", "special-events", "essen-trinken",
+}
+
+// maxPages bounds pagination per listing.
+const maxPages = 20
+
+type Source struct {
+	client     *http.Client
+	categories []string
+}
+
+// Options configures the adapter.
+type Options struct {
+	// Client is used for all requests; nil means httpx.Default().
+	Client *http.Client
+	// Categories fans out over prinz.de's per-category listings (see
+	// CategorySlugs). The main listing often hides events the category
+	// pages show. Empty means the main listing only.
+	Categories []string
+}
+
+func New() *Source {
+	return NewWithOptions(Options{})
+}
+
+func NewWithOptions(opts Options) *Source {
+	client := opts.Client
+	if client == nil {
+		client = httpx.Default()
+	}
+	return &Source{client: client, categories: opts.Categories}
+}
 
-func New() *Source { return &Source{} }
+// CategorySlugs returns the category listings Options.Categories accepts.
+func CategorySlugs() []string {
+	return append([]string(nil), categorySlugs...)
+}
+
+func init() {
+	source.Register("prinz.de", func(cfg source.Config) (source.Source, error) {
+		categories := cfg.List("categories")
+		if len(categories) == 1 && categories[0] == "all" {
+			categories = CategorySlugs()
+		}
+		return NewWithOptions(Options{Categories: categories}), nil
+	})
+}
 
 func (s *Source) ID() string { return "prinz.de" }
 
+// Describe reports a one-week horizon: prinz.de only has listings for
+// today, tomorrow, the weekend and the next 7 days.
+func (s *Source) Describe() source.Description {
+	return source.Description{
+		Text:       "prinz.de Leipzig event listings",
+		Homepage:   baseURL,
+		Horizon:    7 * 24 * time.Hour,
+		Categories: source.MappedCategories(categoryTextMap),
+	}
+}
+
 func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
-	url := pickURL(from, to)
+	rangePath := pickRange(from, to)
+
+	listings := []string{baseURL + rangePath}
+	if len(s.categories) > 0 {
+		listings = listings[:0]
+		for _, slug := range s.categories {
+			listings = append(listings, baseURL+slug+"/"+rangePath)
+		}
+	}
 
+	var (
+		events []model.Event
+		failed int
+	)
+	seen := make(map[string]bool)
+	for _, listing := range listings {
+		page, err := s.fetchListing(ctx, listing)
+		if err != nil {
+			// One broken category listing shouldn't cost the others.
+			if ctx.Err() != nil || len(listings) == 1 {
+				return nil, err
+			}
+			fmt.Fprintf(os.Stderr, "warning: prinz.de listing %s failed: %v\n", listing, err)
+			failed++
+			continue
+		}
+		// The same event shows up in several category listings.
+		for _, e := range page {
+			key := e.URL
+			if key == "" {
+				key = e.Name + "|" + e.StartTime.String() + "|" + e.Venue
+			}
+			if !seen[key] {
+				seen[key] = true
+				events = append(events, e)
+			}
+		}
+	}
+	if failed == len(listings) {
+		return nil, fmt.Errorf("prinz.de: all %d listings failed", failed)
+	}
+	return events, nil
+}
+
+// fetchListing reads a listing and follows its pagination links.
+func (s *Source) fetchListing(ctx context.Context, startURL string) ([]model.Event, error) {
+	var events []model.Event
+	visited := make(map[string]bool)
+
+	url := startURL
+	for page := 0; page < maxPages && url != "" && !visited[url]; page++ {
+		visited[url] = true
+		pageEvents, next, err := s.fetchPage(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, pageEvents...)
+		if len(pageEvents) == 0 {
+			break
+		}
+		url = next
+	}
+	return events, nil
+}
+
+func (s *Source) fetchPage(ctx context.Context, url string) ([]model.Event, string, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("prinzde: create request: %w", err)
+		return nil, "", fmt.Errorf("prinzde: create request: %w", err)
 	}
-	req.Header.Set("User-Agent", "leipzig-cli/1.0")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("prinzde: fetch %s: %w", url, err)
+		return nil, "", fmt.Errorf("prinzde: fetch %s: %w", url, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("prinzde: %s returned %d", url, resp.StatusCode)
+		return nil, "", fmt.Errorf("prinzde: %s returned %d", url, resp.StatusCode)
 	}
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("prinzde: parse HTML: %w", err)
+		return nil, "", fmt.Errorf("prinzde: parse HTML: %w", err)
 	}
 
 	loc, _ := time.LoadLocation("Europe/Berlin")
+	events, next := parsePage(doc, loc)
+	return events, next, nil
+}
+
+// parsePage reads the event teasers on a listing page and the URL of the
+// next page ("mehr anzeigen" or the pager), if any.
+func parsePage(doc *goquery.Document, loc *time.Location) ([]model.Event, string) {
 	var events []model.Event
 
 	doc.Find("article.event-teaser").Each(func(_ int, card *goquery.Selection) {
@@ -66,10 +197,7 @@ func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event,
 		}
 
 		href, _ := titleEl.Attr("href")
-		eventURL := href
-		if eventURL != "" && !strings.HasPrefix(eventURL, "http") {
-			eventURL = "https://prinz.de" + eventURL
-		}
+		eventURL := absURL(href)
 
 		// Category
 		catText := strings.TrimSpace(card.Find(".event-teaser-category").Text())
@@ -104,10 +232,20 @@ func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event,
 		})
 	})
 
-	return events, nil
+	next, _ := doc.Find(`a[rel="next"], .pagination a.next, a.load-more, a.btn-more`).First().Attr("href")
+	return events, absURL(next)
+}
+
+func absURL(href string) string {
+	href = strings.TrimSpace(href)
+	if href != "" && !strings.HasPrefix(href, "http") {
+		return "https://prinz.de" + href
+	}
+	return href
 }
 
-func pickURL(from, to time.Time) string {
+// pickRange returns the listing path suffix for the requested range.
+func pickRange(from, to time.Time) string {
 	loc, _ := time.LoadLocation("Europe/Berlin")
 	now := time.Now().In(loc)
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
@@ -116,21 +254,21 @@ func pickURL(from, to time.Time) string {
 
 	// "week"
 	if duration > 3*24*time.Hour {
-		return baseURL + "7-tage/"
+		return "7-tage/"
 	}
 
 	// "weekend"
 	if duration > 24*time.Hour {
-		return baseURL + "wochenende/"
+		return "wochenende/"
 	}
 
 	// "tomorrow"
 	if from.Year() == tomorrow.Year() && from.Month() == tomorrow.Month() && from.Day() == tomorrow.Day() {
-		return baseURL + "morgen/"
+		return "morgen/"
 	}
 
 	// Default: "today"
-	return baseURL
+	return ""
 }
 
 func mapCategory(text string) string {
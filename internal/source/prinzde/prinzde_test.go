@@ -0,0 +1,164 @@
+package prinzde
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/golden"
+	"github.com/havocked/leipzig-cli/internal/httpx"
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// fakeSite serves pages by URL and records which URLs were requested.
+func fakeSite(pages map[string]string, requested *[]string) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		*requested = append(*requested, r.URL.String())
+		body, ok := pages[r.URL.String()]
+		status := http.StatusOK
+		if !ok {
+			status = http.StatusNotFound
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+			Request:    r,
+		}, nil
+	})}
+}
+
+func teaser(name, href, category, date, clock, venue string) string {
+	return fmt.Sprintf(`<article class="event-teaser">
+  <div class="event-teaser-category">%s</div>
+  <div class="text-primary text-sm-end">%s</div>
+  <h3 class="event-teaser-title"><a href="%s">%s</a></h3>
+  <div class="event-teaser-meta"><span class="fw-bold">%s</span> <span class="text-uppercase">%s</span></div>
+</article>`, category, date, href, name, clock, venue)
+}
+
+func TestFetchFollowsPagination(t *testing.T) {
+	pages := map[string]string{
+		baseURL: "<html><body>" +
+			teaser("Jazz im Keller", "/leipzig/events/jazz", "KONZERTE & LIVEMUSIK", "Sa. 21.02.26", "20:00", "Moritzbastei") +
+			teaser("Stadtführung", "/leipzig/events/fuehrung", "FÜHRUNGEN", "Sa. 21.02.26", "ganztägig", "Markt") +
+			`<a rel="next" href="/leipzig/events/?page=2">mehr</a></body></html>`,
+		baseURL + "?page=2": "<html><body>" +
+			teaser("Kindertheater", "/leipzig/events/kinder", "KINDER & FAMILIE", "Sa. 21.02.26", "15:30", "Theater der Jungen Welt") +
+			"</body></html>",
+	}
+	var requested []string
+	src := NewWithOptions(Options{Client: fakeSite(pages, &requested)})
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	events, err := src.Fetch(context.Background(), today, today.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requested) != 2 {
+		t.Errorf("requested %v, want 2 pages", requested)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+
+	want := []struct {
+		name, category, url string
+		start               time.Time
+	}{
+		{"Jazz im Keller", model.CategoryConcert, "https://prinz.de/leipzig/events/jazz", time.Date(2026, 2, 21, 20, 0, 0, 0, loc)},
+		{"Stadtführung", model.CategoryCulture, "https://prinz.de/leipzig/events/fuehrung", time.Date(2026, 2, 21, 0, 0, 0, 0, loc)},
+		{"Kindertheater", model.CategoryFamily, "https://prinz.de/leipzig/events/kinder", time.Date(2026, 2, 21, 15, 30, 0, 0, loc)},
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.Name != w.name || e.Category != w.category || e.URL != w.url || !e.StartTime.Equal(w.start) {
+			t.Errorf("event %d = %+v, want %+v", i, e, w)
+		}
+	}
+}
+
+func TestFetchCategoriesDeduplicates(t *testing.T) {
+	shared := teaser("Lesung & Konzert", "/leipzig/events/shared", "BÜHNE, KONZERTE & LIVEMUSIK", "So. 22.02.26", "19:00", "UT Connewitz")
+	pages := map[string]string{
+		baseURL + "konzerte/": "<html><body>" + shared + "</body></html>",
+		baseURL + "buehne/": "<html><body>" + shared +
+			teaser("Impro-Show", "/leipzig/events/impro", "BÜHNE", "So. 22.02.26", "20:00", "Schaubühne Lindenfels") +
+			"</body></html>",
+	}
+	var requested []string
+	src := NewWithOptions(Options{Client: fakeSite(pages, &requested), Categories: []string{"konzerte", "buehne"}})
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	events, err := src.Fetch(context.Background(), today, today.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2 after dedup: %+v", len(events), events)
+	}
+	if events[0].Category != model.CategoryTheater {
+		t.Errorf("category = %q, want theater (first known category wins)", events[0].Category)
+	}
+}
+
+func TestFetchCategoryFails(t *testing.T) {
+	pages := map[string]string{
+		baseURL + "konzerte/": "<html><body>" +
+			teaser("Moop Mama", "/leipzig/events/moop", "KONZERTE & LIVEMUSIK", "So. 22.02.26", "20:00", "Conne Island") +
+			"</body></html>",
+	}
+	var requested []string
+	src := NewWithOptions(Options{Client: fakeSite(pages, &requested), Categories: []string{"buehne", "konzerte"}})
+	events, err := src.Fetch(context.Background(), time.Now(), time.Now().Add(time.Hour))
+	if err != nil || len(events) != 1 {
+		t.Errorf("got %d events, %v; want the concert despite the failed category", len(events), err)
+	}
+
+	src = NewWithOptions(Options{Client: fakeSite(nil, &requested), Categories: []string{"buehne", "konzerte"}})
+	if _, err := src.Fetch(context.Background(), time.Now(), time.Now().Add(time.Hour)); err == nil {
+		t.Error("expected error when every category fails")
+	}
+}
+
+func TestFetchHTTPError(t *testing.T) {
+	var requested []string
+	src := NewWithOptions(Options{Client: fakeSite(nil, &requested)})
+	if _, err := src.Fetch(context.Background(), time.Now(), time.Now().Add(time.Hour)); err == nil {
+		t.Error("expected error for 404")
+	}
+}
+
+// Fixtures are refreshed with "leipzig dev record prinz.de".
+const fixtures = "testdata/http"
+
+func TestFetchGolden(t *testing.T) {
+	rec, err := httpx.LoadRecording(fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := NewWithOptions(Options{Client: httpx.Replay(fixtures).Client()})
+
+	events, err := src.Fetch(context.Background(), rec.From, rec.To)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) == 0 {
+		t.Fatal("no events parsed from recorded pages")
+	}
+	golden.AssertJSON(t, "testdata/fetch.golden.json", events)
+}
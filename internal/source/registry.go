@@ -0,0 +1,164 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config is a source's section of the config file.
+type Config struct {
+	URLs    []string
+	Options map[string]string
+}
+
+// Factory builds a source from its config.
+type Factory func(Config) (Source, error)
+
+// Description is a source's self-reported metadata.
+type Description struct {
+	Text     string `json:"description"`
+	Homepage string `json:"homepage,omitempty"`
+	// Horizon is how far ahead the source lists events; 0 means no limit.
+	Horizon time.Duration `json:"-"`
+	// Categories are the model categories the source emits.
+	Categories []string `json:"categories,omitempty"`
+	// NeedsURLs means the source does nothing without Config.URLs.
+	NeedsURLs bool `json:"needsUrls,omitempty"`
+	// NeedsAPIKey means the source needs Config.Options["apiKey"].
+	NeedsAPIKey bool `json:"needsApiKey,omitempty"`
+}
+
+// Describer is implemented by sources that report their metadata.
+type Describer interface {
+	Describe() Description
+}
+
+var (
+	// ErrUnknown is returned by New for IDs nobody registered.
+	ErrUnknown = errors.New("unknown source")
+	// ErrNotConfigured is returned by New when a source lacks the URLs or
+	// API key its Description says it needs.
+	ErrNotConfigured = errors.New("source not configured")
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes a source available under id. Adapters call it from init;
+// registering an ID twice panics.
+func Register(id string, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[id]; dup {
+		panic("source: Register called twice for " + id)
+	}
+	registry[id] = f
+}
+
+// Lookup returns the factory registered under id.
+func Lookup(id string) (Factory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	f, ok := registry[id]
+	return f, ok
+}
+
+// IDs returns the registered source IDs, sorted.
+func IDs() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	ids := make([]string, 0, len(registry))
+	for id := range registry {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Describe returns the description of a registered source, built from an
+// empty config.
+func Describe(id string) (Description, bool) {
+	f, ok := Lookup(id)
+	if !ok {
+		return Description{}, false
+	}
+	src, err := f(Config{})
+	if err != nil || src == nil {
+		return Description{}, false
+	}
+	return DescriptionOf(src)
+}
+
+// DescriptionOf returns src's description, looking through wrappers that
+// implement Unwrap.
+func DescriptionOf(src Source) (Description, bool) {
+	for src != nil {
+		if d, ok := src.(Describer); ok {
+			return d.Describe(), true
+		}
+		u, ok := src.(interface{ Unwrap() Source })
+		if !ok {
+			break
+		}
+		src = u.Unwrap()
+	}
+	return Description{}, false
+}
+
+// New builds the registered source id from cfg.
+func New(id string, cfg Config) (Source, error) {
+	f, ok := Lookup(id)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknown, id)
+	}
+	if err := checkConfig(id, cfg); err != nil {
+		return nil, err
+	}
+	return f(cfg)
+}
+
+// checkConfig reports ErrNotConfigured if cfg lacks what the source's
+// description says it needs.
+func checkConfig(id string, cfg Config) error {
+	d, _ := Describe(id)
+	switch {
+	case d.NeedsURLs && len(cfg.URLs) == 0:
+		return fmt.Errorf("%s: %w (no urls)", id, ErrNotConfigured)
+	case d.NeedsAPIKey && cfg.Options["apiKey"] == "":
+		return fmt.Errorf("%s: %w (no apiKey)", id, ErrNotConfigured)
+	}
+	return nil
+}
+
+// List returns the comma-separated option key as a list, dropping empty
+// items.
+func (c Config) List(key string) []string {
+	var out []string
+	for _, item := range strings.Split(c.Options[key], ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// MappedCategories returns the distinct values of an adapter's
+// site-category mapping, sorted, for Description.Categories.
+func MappedCategories(m map[string]string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, c := range m {
+		if !seen[c] {
+			seen[c] = true
+			out = append(out, c)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
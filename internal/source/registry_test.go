@@ -0,0 +1,59 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+type testSource struct{ urls []string }
+
+func (s *testSource) ID() string { return "test-registry" }
+
+func (s *testSource) Fetch(context.Context, time.Time, time.Time) ([]model.Event, error) {
+	return nil, nil
+}
+
+func (s *testSource) Describe() Description {
+	return Description{Text: "test", NeedsURLs: true}
+}
+
+type wrapper struct{ Source }
+
+func (w wrapper) Unwrap() Source { return w.Source }
+
+func TestRegistry(t *testing.T) {
+	Register("test-registry", func(cfg Config) (Source, error) { return &testSource{urls: cfg.URLs}, nil })
+
+	if _, err := New("test-registry", Config{}); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("New without urls: err = %v, want ErrNotConfigured", err)
+	}
+	src, err := New("test-registry", Config{URLs: []string{"https://example.org/feed.ics"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, ok := DescriptionOf(wrapper{src}); !ok || d.Text != "test" {
+		t.Errorf("DescriptionOf(wrapped) = %+v, %v", d, ok)
+	}
+	if _, err := New("nope", Config{}); !errors.Is(err, ErrUnknown) {
+		t.Errorf("New(nope): err = %v, want ErrUnknown", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("duplicate Register did not panic")
+		}
+	}()
+	Register("test-registry", nil)
+}
+
+func TestConfigList(t *testing.T) {
+	cfg := Config{Options: map[string]string{"topics": " Konzert, ,Lesung "}}
+	if got, want := cfg.List("topics"), []string{"Konzert", "Lesung"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List = %q, want %q", got, want)
+	}
+}
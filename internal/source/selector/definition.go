@@ -0,0 +1,172 @@
+package selector
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Definition describes how to scrape one listing page. It is loaded from a
+// YAML file, usually ~/.config/leipzig/sources.d/<id>.yaml.
+type Definition struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description,omitempty"`
+	URL         string `yaml:"url"`
+
+	// List selects one element per event.
+	List string `yaml:"list"`
+	// Fields maps event fields to selectors relative to the list element.
+	// A selector may end in "@attr" to read an attribute instead of text.
+	Fields Fields `yaml:"fields"`
+
+	// Venue is used when the page has no venue field (single-venue sites).
+	Venue   string `yaml:"venue,omitempty"`
+	Address string `yaml:"address,omitempty"`
+
+	// DateLayouts and TimeLayouts are Go time layouts tried in order.
+	DateLayouts []string `yaml:"date_layouts,omitempty"`
+	TimeLayouts []string `yaml:"time_layouts,omitempty"`
+	// DatePattern and TimePattern optionally extract the part of the text
+	// to parse; the first capture group (or whole match) is used.
+	DatePattern string `yaml:"date_pattern,omitempty"`
+	TimePattern string `yaml:"time_pattern,omitempty"`
+
+	// Categories maps the page's category text (case-insensitive) to a
+	// canonical category. DefaultCategory applies when nothing matches.
+	Categories      map[string]string `yaml:"categories,omitempty"`
+	DefaultCategory string            `yaml:"default_category,omitempty"`
+
+	// Next selects the pagination link; MaxPages bounds how many pages are read.
+	Next     string `yaml:"next,omitempty"`
+	MaxPages int    `yaml:"max_pages,omitempty"`
+
+	dateRe *regexp.Regexp
+	timeRe *regexp.Regexp
+	path   string
+}
+
+// Fields lists the selectors for each event field.
+type Fields struct {
+	Name        string `yaml:"name"`
+	URL         string `yaml:"url,omitempty"`
+	Date        string `yaml:"date"`
+	Time        string `yaml:"time,omitempty"`
+	EndTime     string `yaml:"end_time,omitempty"`
+	Venue       string `yaml:"venue,omitempty"`
+	Address     string `yaml:"address,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Price       string `yaml:"price,omitempty"`
+	Image       string `yaml:"image,omitempty"`
+	Category    string `yaml:"category,omitempty"`
+}
+
+// LoadFile reads and validates a definition.
+func LoadFile(path string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("selector: read %s: %w", path, err)
+	}
+
+	var def Definition
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("selector: parse %s: %w", path, err)
+	}
+	def.path = path
+	if def.ID == "" {
+		def.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := def.validate(); err != nil {
+		return nil, fmt.Errorf("selector: %s: %w", path, err)
+	}
+	return &def, nil
+}
+
+// LoadDir reads every *.yaml/*.yml definition in dir, sorted by file name.
+// A missing directory yields no definitions. Invalid files are reported in
+// the returned error but don't prevent the others from loading.
+func LoadDir(dir string) ([]*Definition, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("selector: read %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var (
+		defs []*Definition
+		errs []error
+	)
+	for _, name := range names {
+		def, err := LoadFile(filepath.Join(dir, name))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		defs = append(defs, def)
+	}
+	return defs, errors.Join(errs...)
+}
+
+// Path returns the file the definition was loaded from.
+func (d *Definition) Path() string { return d.path }
+
+func (d *Definition) validate() error {
+	switch {
+	case d.List == "":
+		return errors.New("missing list selector")
+	case d.Fields.Name == "":
+		return errors.New("missing fields.name selector")
+	case d.Fields.Date == "":
+		return errors.New("missing fields.date selector")
+	}
+	if len(d.DateLayouts) == 0 {
+		d.DateLayouts = []string{"02.01.2006", "2.1.2006", "02.01.06", "2006-01-02"}
+	}
+	if len(d.TimeLayouts) == 0 {
+		d.TimeLayouts = []string{"15:04", "15.04", "15"}
+	}
+	if d.DefaultCategory == "" {
+		d.DefaultCategory = model.CategoryOther
+	}
+	if !model.ValidCategory(d.DefaultCategory) {
+		return fmt.Errorf("unknown default_category %q", d.DefaultCategory)
+	}
+	for k, v := range d.Categories {
+		if !model.ValidCategory(v) {
+			return fmt.Errorf("categories.%s: unknown category %q", k, v)
+		}
+	}
+
+	var err error
+	if d.DatePattern != "" {
+		if d.dateRe, err = regexp.Compile(d.DatePattern); err != nil {
+			return fmt.Errorf("date_pattern: %w", err)
+		}
+	}
+	if d.TimePattern != "" {
+		if d.timeRe, err = regexp.Compile(d.TimePattern); err != nil {
+			return fmt.Errorf("time_pattern: %w", err)
+		}
+	}
+	return nil
+}
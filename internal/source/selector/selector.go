@@ -0,0 +1,288 @@
+// Package selector is a generic source adapter driven by a declarative
+// definition of CSS selectors, date layouts and a category map, so a small
+// venue site can be added as a YAML file instead of a Go package.
+package selector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/havocked/leipzig-cli/internal/httpx"
+	"github.com/havocked/leipzig-cli/internal/model"
+	"github.com/havocked/leipzig-cli/internal/source"
+)
+
+const defaultMaxPages = 5
+
+type Source struct {
+	def    *Definition
+	client *http.Client
+}
+
+func New(def *Definition) *Source {
+	return &Source{def: def, client: httpx.Default()}
+}
+
+func (s *Source) ID() string { return s.def.ID }
+
+func (s *Source) Describe() source.Description {
+	text := s.def.Description
+	if text == "" {
+		text = s.def.URL
+	}
+	mapped := map[string]string{"": s.def.DefaultCategory}
+	for k, v := range s.def.Categories {
+		mapped[k] = v
+	}
+	return source.Description{
+		Text:       text + " (selector)",
+		Homepage:   s.def.URL,
+		Categories: source.MappedCategories(mapped),
+	}
+}
+
+// Definition returns the definition the source was built from.
+func (s *Source) Definition() *Definition { return s.def }
+
+func (s *Source) Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error) {
+	maxPages := s.def.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+
+	var all []model.Event
+	visited := make(map[string]bool)
+	pageURL := s.def.URL
+
+	for page := 0; page < maxPages && pageURL != "" && !visited[pageURL]; page++ {
+		visited[pageURL] = true
+
+		events, next, err := s.fetchPage(ctx, pageURL)
+		if err != nil {
+			return nil, fmt.Errorf("%s: fetch %s: %w", s.def.ID, pageURL, err)
+		}
+		for _, e := range events {
+			if !e.Overlaps(from, to) {
+				continue
+			}
+			all = append(all, e)
+		}
+		pageURL = next
+	}
+	return all, nil
+}
+
+func (s *Source) fetchPage(ctx context.Context, pageURL string) ([]model.Event, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return s.def.Parse(resp.Body, pageURL, time.Now())
+}
+
+// Parse extracts events from one page. pageURL resolves relative links and
+// ref anchors dates without a year. It also returns the next page's URL, if
+// the definition has a pagination selector and the page links onward.
+func (d *Definition) Parse(r io.Reader, pageURL string, ref time.Time) ([]model.Event, string, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: parse HTML: %w", d.ID, err)
+	}
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	base, _ := url.Parse(pageURL)
+
+	var events []model.Event
+	doc.Find(d.List).Each(func(_ int, item *goquery.Selection) {
+		e := model.Event{
+			Name:        extract(item, d.Fields.Name),
+			Description: extract(item, d.Fields.Description),
+			Venue:       extract(item, d.Fields.Venue),
+			Address:     extract(item, d.Fields.Address),
+			Price:       extract(item, d.Fields.Price),
+			URL:         resolve(base, extract(item, d.Fields.URL)),
+			ImageURL:    resolve(base, extract(item, d.Fields.Image)),
+			Source:      d.ID,
+		}
+		if e.Name == "" {
+			return
+		}
+		if e.Venue == "" {
+			e.Venue = d.Venue
+		}
+		if e.Address == "" {
+			e.Address = d.Address
+		}
+
+		date := d.parseDate(extract(item, d.Fields.Date), ref, loc)
+		if date.IsZero() {
+			return
+		}
+		e.StartTime = d.applyTime(date, extract(item, d.Fields.Time), loc)
+		if d.Fields.EndTime != "" {
+			if end := d.applyTime(date, extract(item, d.Fields.EndTime), loc); !end.Equal(date) {
+				if end.Before(e.StartTime) {
+					end = end.AddDate(0, 0, 1)
+				}
+				e.EndTime = end
+			}
+		}
+
+		e.Category = d.mapCategory(extract(item, d.Fields.Category))
+		if e.Category == model.CategoryOther {
+			e.Category = model.InferCategory(e.Name, e.Venue)
+		}
+		events = append(events, e)
+	})
+
+	next := ""
+	if d.Next != "" {
+		next = resolve(base, extract(doc.Selection, d.Next))
+	}
+	return events, next, nil
+}
+
+// extract applies "selector" or "selector @attr" to sel. An empty selector
+// or "@attr" alone refers to sel itself.
+func extract(sel *goquery.Selection, spec string) string {
+	if spec == "" {
+		return ""
+	}
+	query, attr := spec, ""
+	if i := strings.LastIndex(spec, "@"); i >= 0 {
+		query, attr = strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:])
+	}
+
+	target := sel
+	if query != "" {
+		target = sel.Find(query).First()
+	}
+	if attr != "" {
+		v, _ := target.Attr(attr)
+		return strings.TrimSpace(v)
+	}
+	return strings.Join(strings.Fields(target.Text()), " ")
+}
+
+func resolve(base *url.URL, href string) string {
+	if href == "" {
+		return ""
+	}
+	u, err := url.Parse(href)
+	if err != nil || base == nil {
+		return href
+	}
+	return base.ResolveReference(u).String()
+}
+
+// germanMonths translates month names so Go layouts like "2. January 2006" work.
+var germanMonths = strings.NewReplacer(
+	"Januar", "January", "Februar", "February", "März", "March", "Mai", "May",
+	"Juni", "June", "Juli", "July", "Oktober", "October", "Dezember", "December",
+	"Jän", "Jan", "Mär", "Mar", "Okt", "Oct", "Dez", "Dec",
+)
+
+func (d *Definition) parseDate(text string, ref time.Time, loc *time.Location) time.Time {
+	text = applyPattern(d.dateRe, text)
+	if text == "" {
+		return time.Time{}
+	}
+	text = germanMonths.Replace(text)
+
+	for _, layout := range d.DateLayouts {
+		t, err := time.ParseInLocation(layout, text, loc)
+		if err != nil {
+			continue
+		}
+		if t.Year() == 0 {
+			// No year in the layout: pick the occurrence closest after ref,
+			// allowing listings to lag a week behind.
+			ref = ref.In(loc)
+			t = time.Date(ref.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+			if t.Before(ref.AddDate(0, 0, -7)) {
+				t = t.AddDate(1, 0, 0)
+			}
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (d *Definition) applyTime(date time.Time, text string, loc *time.Location) time.Time {
+	text = applyPattern(d.timeRe, text)
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "Uhr"))
+	if text == "" {
+		return date
+	}
+	for _, layout := range d.TimeLayouts {
+		if t, err := time.ParseInLocation(layout, text, loc); err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+		}
+	}
+	return date
+}
+
+func (d *Definition) mapCategory(text string) string {
+	text = strings.TrimSpace(text)
+	for k, v := range d.Categories {
+		if strings.EqualFold(k, text) {
+			return v
+		}
+	}
+	if text == "" {
+		return d.DefaultCategory
+	}
+
+	// Partial match for compound labels like "Konzert / Indie"; longer keys
+	// first so "Kinderkonzert" beats "Konzert".
+	keys := make([]string, 0, len(d.Categories))
+	for k := range d.Categories {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	lower := strings.ToLower(text)
+	for _, k := range keys {
+		if strings.Contains(lower, strings.ToLower(k)) {
+			return d.Categories[k]
+		}
+	}
+	return d.DefaultCategory
+}
+
+func applyPattern(re *regexp.Regexp, text string) string {
+	if re == nil {
+		return strings.TrimSpace(text)
+	}
+	m := re.FindStringSubmatch(text)
+	switch {
+	case m == nil:
+		return ""
+	case len(m) > 1:
+		return strings.TrimSpace(m[1])
+	default:
+		return strings.TrimSpace(m[0])
+	}
+}
@@ -0,0 +1,76 @@
+package selector
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+func TestParseFixture(t *testing.T) {
+	def, err := LoadFile("testdata/werk2.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open("testdata/werk2.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	ref := time.Date(2026, 3, 1, 0, 0, 0, 0, loc)
+	events, next, err := def.Parse(f, "https://www.werk-2.de/programm", ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next != "https://www.werk-2.de/programm?page=2" {
+		t.Errorf("next = %q", next)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3: %+v", len(events), events)
+	}
+
+	first := events[0]
+	want := model.Event{
+		Name:        "Isolation Berlin",
+		Description: "Berliner Indie mit deutschen Texten.",
+		StartTime:   time.Date(2026, 3, 6, 20, 0, 0, 0, loc),
+		Venue:       "Werk 2 – Halle A",
+		Address:     "Kochstraße 132, 04277 Leipzig",
+		Category:    model.CategoryConcert,
+		Price:       "VVK 26 €",
+		URL:         "https://www.werk-2.de/programm/isolation-berlin",
+		ImageURL:    "https://www.werk-2.de/media/isolation.jpg",
+		Source:      "werk2",
+	}
+	if first.Name != want.Name || first.Description != want.Description || first.Venue != want.Venue ||
+		first.Address != want.Address || first.Category != want.Category || first.Price != want.Price ||
+		first.URL != want.URL || first.ImageURL != want.ImageURL || first.Source != want.Source {
+		t.Errorf("event 0 = %+v\nwant %+v", first, want)
+	}
+	if !first.StartTime.Equal(want.StartTime) {
+		t.Errorf("start = %v, want %v", first.StartTime, want.StartTime)
+	}
+
+	if got := events[1]; got.Category != model.CategoryNightlife || got.StartTime.Hour() != 22 {
+		t.Errorf("event 1 = %+v", got)
+	}
+	// "Lesung" isn't in the category map, so default_category applies.
+	if got := events[2]; got.Category != model.CategoryConcert || got.StartTime.Hour() != 0 {
+		t.Errorf("event 2 = %+v", got)
+	}
+}
+
+func TestLoadFileValidates(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/broken.yaml"
+	if err := os.WriteFile(path, []byte("url: https://example.org\nlist: li\nfields:\n  name: h3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("expected error for definition without fields.date")
+	}
+}
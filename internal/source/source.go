@@ -2,6 +2,10 @@ package source
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/havocked/leipzig-cli/internal/model"
@@ -11,3 +15,54 @@ type Source interface {
 	ID() string
 	Fetch(ctx context.Context, from, to time.Time) ([]model.Event, error)
 }
+
+// ErrNoDetails is returned by DetailFetcher implementations that wrap a
+// source without detail pages.
+var ErrNoDetails = errors.New("source has no detail pages")
+
+// DetailFetcher is implemented by sources that can read an event's detail
+// page (its URL) for information the list page doesn't show.
+type DetailFetcher interface {
+	FetchDetails(ctx context.Context, e model.Event) (Details, error)
+}
+
+// Details holds the fields read from a detail page.
+type Details struct {
+	Description string    `json:"description,omitempty"`
+	Address     string    `json:"address,omitempty"`
+	Price       string    `json:"price,omitempty"`
+	Organizer   string    `json:"organizer,omitempty"`
+	EndTime     time.Time `json:"endTime,omitzero"`
+}
+
+// Apply fills in the fields of e that are empty or, for the description,
+// shorter than the detail page's.
+func (d Details) Apply(e *model.Event) {
+	if len(d.Description) > len(e.Description) {
+		e.Description = d.Description
+	}
+	if e.Address == "" {
+		e.Address = d.Address
+	}
+	if e.Price == "" {
+		e.Price = d.Price
+	}
+	if e.Organizer == "" {
+		e.Organizer = d.Organizer
+	}
+	if e.EndTime.IsZero() && d.EndTime.After(e.StartTime) {
+		e.EndTime = d.EndTime
+	}
+}
+
+// OpenFile opens a local feed or page given as a path or file:// URL; a
+// leading ~/ stands for the home directory.
+func OpenFile(name string) (*os.File, error) {
+	name = strings.TrimPrefix(name, "file://")
+	if strings.HasPrefix(name, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			name = filepath.Join(home, name[2:])
+		}
+	}
+	return os.Open(name)
+}
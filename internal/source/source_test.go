@@ -0,0 +1,73 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+func TestDetailsApply(t *testing.T) {
+	start := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	d := Details{
+		Description: "Die Münchner Brass-Band kommt mit neuem Album.",
+		Address:     "Koburger Straße 3",
+		Price:       "25 €",
+		Organizer:   "Conne Island e.V.",
+		EndTime:     start.Add(3 * time.Hour),
+	}
+
+	// Empty fields are filled in.
+	var e model.Event
+	e.StartTime = start
+	d.Apply(&e)
+	if e.Description != d.Description || e.Address != d.Address || e.Price != d.Price ||
+		e.Organizer != d.Organizer || !e.EndTime.Equal(d.EndTime) {
+		t.Errorf("empty event: %+v", e)
+	}
+
+	// Known fields are kept; only a longer description replaces a teaser.
+	e = model.Event{
+		Description: "Brass-Rap",
+		Address:     "Koburger Str. 3, Leipzig",
+		Price:       "VVK 22 €",
+		Organizer:   "Conne Island",
+		StartTime:   start,
+		EndTime:     start.Add(2 * time.Hour),
+	}
+	want := e
+	want.Description = d.Description
+	d.Apply(&e)
+	if e.Description != want.Description || e.Address != want.Address || e.Price != want.Price ||
+		e.Organizer != want.Organizer || !e.EndTime.Equal(want.EndTime) {
+		t.Errorf("known fields overwritten: %+v", e)
+	}
+
+	// An end before the start is ignored.
+	e = model.Event{StartTime: start}
+	Details{EndTime: start.Add(-time.Hour)}.Apply(&e)
+	if !e.EndTime.IsZero() {
+		t.Errorf("EndTime = %v", e.EndTime)
+	}
+}
+
+func TestOpenFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, "feed.ics"), []byte("BEGIN:VCALENDAR"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"~/feed.ics", "file://~/feed.ics", filepath.Join(home, "feed.ics"), "file://" + filepath.Join(home, "feed.ics")} {
+		f, err := OpenFile(name)
+		if err != nil {
+			t.Errorf("OpenFile(%q): %v", name, err)
+			continue
+		}
+		f.Close()
+	}
+	if _, err := OpenFile("~/missing.ics"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -0,0 +1,253 @@
+// Package timerange resolves --when expressions such as "weekend",
+// "next friday", "in 3 days", "easter", "nächstes wochenende" or
+// "2026-03-01..2026-03-07" to a time range.
+//
+// Ranges are whole days in the location of the reference time. Day
+// arithmetic goes through time.Date, so days on which the clocks change
+// are 23 or 25 hours long rather than being cut or stretched to 24.
+package timerange
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Range is the half-open interval [From, To).
+type Range struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// String formats r for humans, with the last day inclusive:
+// "Sat 24 Oct 2026 – Sun 25 Oct 2026".
+func (r Range) String() string {
+	const day, minute = "Mon 02 Jan 2006", "Mon 02 Jan 2006 15:04"
+	if !midnight(r.From) || !midnight(r.To) {
+		return r.From.Format(minute) + " – " + r.To.Format(minute)
+	}
+	last := addDays(r.To, -1)
+	if last.Equal(r.From) {
+		return r.From.Format(day)
+	}
+	return r.From.Format(day) + " – " + last.Format(day)
+}
+
+// Days returns the range of n days starting on the day of t.
+func Days(t time.Time, n int) Range {
+	from := startOfDay(t)
+	return Range{From: from, To: addDays(from, n)}
+}
+
+// Parse resolves expr relative to now. Expressions are case-insensitive
+// and may be English or German; "a..b" spans from the start of a to the
+// end of b.
+func Parse(expr string, now time.Time) (Range, error) {
+	s := normalize(expr)
+	if s == "" {
+		return Range{}, fmt.Errorf("timerange: empty expression")
+	}
+	if a, b, ok := strings.Cut(s, ".."); ok && a != "" && b != "" {
+		from, err := parseTerm(strings.TrimSpace(a), now)
+		if err != nil {
+			return Range{}, fmt.Errorf("timerange: %q: %w", expr, err)
+		}
+		to, err := parseTerm(strings.TrimSpace(b), now)
+		if err != nil {
+			return Range{}, fmt.Errorf("timerange: %q: %w", expr, err)
+		}
+		if !to.To.After(from.From) {
+			return Range{}, fmt.Errorf("timerange: %q ends before it starts", expr)
+		}
+		return Range{From: from.From, To: to.To}, nil
+	}
+	r, err := parseTerm(s, now)
+	if err != nil {
+		return Range{}, fmt.Errorf("timerange: %q: %w", expr, err)
+	}
+	return r, nil
+}
+
+var (
+	weekdays = map[string]time.Weekday{
+		"monday": time.Monday, "montag": time.Monday,
+		"tuesday": time.Tuesday, "dienstag": time.Tuesday,
+		"wednesday": time.Wednesday, "mittwoch": time.Wednesday,
+		"thursday": time.Thursday, "donnerstag": time.Thursday,
+		"friday": time.Friday, "freitag": time.Friday,
+		"saturday": time.Saturday, "samstag": time.Saturday, "sonnabend": time.Saturday,
+		"sunday": time.Sunday, "sonntag": time.Sunday,
+	}
+
+	// "this"/"next" and their German forms, after normalize.
+	thisWords = `this|diese|dieses|diesen|dieser|am`
+	nextWords = `next|naechste|naechstes|naechsten|naechster|kommende|kommendes|kommenden|kommender`
+
+	nextExpr    = regexp.MustCompile(`^(?:` + nextWords + `)$`)
+	weekdayExpr = regexp.MustCompile(`^(?:(` + thisWords + `|` + nextWords + `) )?([a-z]+)$`)
+	inExpr      = regexp.MustCompile(`^in (\d+) (days?|tagen?|weeks?|wochen?)$`)
+	nextNExpr   = regexp.MustCompile(`^(?:` + nextWords + `) (\d+) (days|tage|tagen)$`)
+	unitExpr    = regexp.MustCompile(`^(?:(` + thisWords + `|` + nextWords + `) )?(weekend|wochenende|week|woche|month|monat)$`)
+	easterExpr  = regexp.MustCompile(`^(?:easter|ostern)(?: (\d{4}))?$`)
+	germanDate  = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.(\d{4})?$`)
+)
+
+// parseTerm resolves one expression without "..".
+func parseTerm(s string, now time.Time) (Range, error) {
+	today := startOfDay(now)
+	switch s {
+	case "today", "heute":
+		return Days(today, 1), nil
+	case "tomorrow", "morgen":
+		return Days(addDays(today, 1), 1), nil
+	case "day after tomorrow", "uebermorgen":
+		return Days(addDays(today, 2), 1), nil
+	case "week", "woche":
+		// The next seven days, as --when week has always meant.
+		return Days(today, 7), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return Days(t, 1), nil
+	}
+	if m := germanDate.FindStringSubmatch(s); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		year := now.Year()
+		if m[3] != "" {
+			year, _ = strconv.Atoi(m[3])
+		}
+		t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, now.Location())
+		if t.Day() != day || t.Month() != time.Month(month) {
+			return Range{}, fmt.Errorf("no such date")
+		}
+		if m[3] == "" && t.Before(today) {
+			t = t.AddDate(1, 0, 0) // "24.12." means the next one
+		}
+		return Days(t, 1), nil
+	}
+
+	if m := inExpr.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if strings.HasPrefix(m[2], "week") || strings.HasPrefix(m[2], "woche") {
+			n *= 7
+		}
+		return Days(addDays(today, n), 1), nil
+	}
+	if m := nextNExpr.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if n < 1 {
+			return Range{}, fmt.Errorf("need at least 1 day")
+		}
+		return Days(today, n), nil
+	}
+
+	if m := unitExpr.FindStringSubmatch(s); m != nil {
+		next := nextExpr.MatchString(m[1])
+		switch m[2] {
+		case "weekend", "wochenende":
+			return weekend(today, next), nil
+		case "week", "woche": // "this week" ends on Sunday
+			monday := addDays(today, -daysSince(today.Weekday(), time.Monday))
+			if next {
+				return Days(addDays(monday, 7), 7), nil
+			}
+			return Range{From: today, To: addDays(monday, 7)}, nil
+		default: // month
+			first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+			if next {
+				return Range{From: first.AddDate(0, 1, 0), To: first.AddDate(0, 2, 0)}, nil
+			}
+			return Range{From: today, To: first.AddDate(0, 1, 0)}, nil
+		}
+	}
+
+	if m := weekdayExpr.FindStringSubmatch(s); m != nil {
+		if wd, ok := weekdays[m[2]]; ok {
+			n := (int(wd) - int(today.Weekday()) + 7) % 7
+			if n == 0 && nextExpr.MatchString(m[1]) {
+				n = 7 // "next friday" on a Friday is a week away
+			}
+			return Days(addDays(today, n), 1), nil
+		}
+	}
+
+	if m := easterExpr.FindStringSubmatch(s); m != nil {
+		if m[1] != "" {
+			year, _ := strconv.Atoi(m[1])
+			return easterWeekend(year, now.Location()), nil
+		}
+		r := easterWeekend(today.Year(), now.Location())
+		if !r.To.After(today) {
+			r = easterWeekend(today.Year()+1, now.Location())
+		}
+		return r, nil
+	}
+
+	return Range{}, fmt.Errorf("unknown time expression")
+}
+
+// weekend returns Saturday and Sunday of this weekend, from today if it has
+// already begun, or of the one after.
+func weekend(today time.Time, next bool) Range {
+	var sat time.Time
+	switch today.Weekday() {
+	case time.Saturday:
+		sat = today
+	case time.Sunday:
+		sat = addDays(today, -1)
+	default:
+		sat = addDays(today, int(time.Saturday-today.Weekday()))
+	}
+	if next {
+		return Days(addDays(sat, 7), 2)
+	}
+	r := Days(sat, 2)
+	if r.From.Before(today) {
+		r.From = today
+	}
+	return r
+}
+
+// easterWeekend returns Good Friday to Easter Monday of year.
+func easterWeekend(year int, loc *time.Location) Range {
+	// Anonymous Gregorian algorithm (Meeus/Jones/Butcher).
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	sunday := time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+	return Range{From: addDays(sunday, -2), To: addDays(sunday, 2)}
+}
+
+// normalize lowercases s, spells out umlauts and collapses spaces.
+func normalize(s string) string {
+	s = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss").Replace(strings.ToLower(s))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func addDays(t time.Time, n int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+n, 0, 0, 0, 0, t.Location())
+}
+
+func midnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0
+}
+
+// daysSince returns how many days ago the last wd (today included) was.
+func daysSince(today, wd time.Weekday) int {
+	return (int(today) - int(wd) + 7) % 7
+}
@@ -0,0 +1,98 @@
+package timerange
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	day := func(s string) time.Time {
+		d, err := time.ParseInLocation("2006-01-02", s, berlin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	wed := time.Date(2026, 3, 4, 15, 30, 0, 0, berlin) // Wednesday
+	sun := time.Date(2026, 3, 8, 11, 0, 0, 0, berlin)
+	tests := []struct {
+		expr     string
+		now      time.Time
+		from, to string
+	}{
+		{"today", wed, "2026-03-04", "2026-03-05"},
+		{"Morgen", wed, "2026-03-05", "2026-03-06"},
+		{"übermorgen", wed, "2026-03-06", "2026-03-07"},
+		{"week", wed, "2026-03-04", "2026-03-11"},
+		{"this week", wed, "2026-03-04", "2026-03-09"},
+		{"next week", wed, "2026-03-09", "2026-03-16"},
+		{"weekend", wed, "2026-03-07", "2026-03-09"},
+		{"weekend", sun, "2026-03-08", "2026-03-09"},
+		{"next weekend", wed, "2026-03-14", "2026-03-16"},
+		{"nächstes  Wochenende", wed, "2026-03-14", "2026-03-16"},
+		{"naechstes wochenende", sun, "2026-03-14", "2026-03-16"},
+		{"friday", wed, "2026-03-06", "2026-03-07"},
+		{"next friday", wed, "2026-03-06", "2026-03-07"},
+		{"next wednesday", wed, "2026-03-11", "2026-03-12"},
+		{"wednesday", wed, "2026-03-04", "2026-03-05"},
+		{"am samstag", wed, "2026-03-07", "2026-03-08"},
+		{"in 3 days", wed, "2026-03-07", "2026-03-08"},
+		{"in 2 wochen", wed, "2026-03-18", "2026-03-19"},
+		{"next 3 days", wed, "2026-03-04", "2026-03-07"},
+		{"this month", wed, "2026-03-04", "2026-04-01"},
+		{"nächsten monat", wed, "2026-04-01", "2026-05-01"},
+		{"easter", wed, "2026-04-03", "2026-04-07"},
+		{"ostern 2027", wed, "2027-03-26", "2027-03-30"},
+		{"easter", day("2026-04-07"), "2027-03-26", "2027-03-30"},
+		{"2026-03-01", wed, "2026-03-01", "2026-03-02"},
+		{"2026-03-01..2026-03-07", wed, "2026-03-01", "2026-03-08"},
+		{"today..friday", wed, "2026-03-04", "2026-03-07"},
+		{"24.12.", wed, "2026-12-24", "2026-12-25"},
+		{"1.3.2026", wed, "2026-03-01", "2026-03-02"},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.expr, tt.now)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.expr, err)
+			continue
+		}
+		if !r.From.Equal(day(tt.from)) || !r.To.Equal(day(tt.to)) {
+			t.Errorf("Parse(%q) = %s .. %s, want %s .. %s", tt.expr,
+				r.From.Format(time.DateOnly), r.To.Format(time.DateOnly), tt.from, tt.to)
+		}
+	}
+
+	for _, expr := range []string{"", "someday", "2026-02-30", "31.4.", "2026-03-07..2026-03-01", "next 0 days"} {
+		if r, err := Parse(expr, wed); err == nil {
+			t.Errorf("Parse(%q) = %v, want error", expr, r)
+		}
+	}
+}
+
+func TestParseDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	// Clocks go back on Sunday 25 Oct 2026: the weekend is 49 hours long
+	// and still ends at midnight.
+	r, err := Parse("weekend", time.Date(2026, 10, 23, 12, 0, 0, 0, berlin))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := r.To.Sub(r.From).Hours(); h != 49 || r.To.Hour() != 0 {
+		t.Errorf("weekend = %v (%vh)", r, h)
+	}
+	// Clocks go forward on Sunday 29 Mar 2026.
+	r, _ = Parse("tomorrow", time.Date(2026, 3, 28, 23, 30, 0, 0, berlin))
+	if h := r.To.Sub(r.From).Hours(); h != 23 || r.To.Day() != 30 || r.To.Hour() != 0 {
+		t.Errorf("tomorrow = %v (%vh)", r, h)
+	}
+	if got := r.String(); got != "Sun 29 Mar 2026" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,213 @@
+// Package venue is a registry of Leipzig venues. Sources write venue names
+// however they like; Match maps them to one canonical entry with an
+// address, district and coordinates.
+package venue
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/havocked/leipzig-cli/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed venues.yaml
+var builtin []byte
+
+// minPrefixLen is the shortest key Match accepts as the start of a longer name.
+const minPrefixLen = 5
+
+// Venue is one place where events happen.
+type Venue struct {
+	// ID is stable and used as model.Event.VenueID.
+	ID      string   `yaml:"id" json:"id"`
+	Name    string   `yaml:"name" json:"name"`
+	Aliases []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	Address string   `yaml:"address,omitempty" json:"address,omitempty"`
+	// Ortsteil and Stadtbezirk are Leipzig's district and borough.
+	Ortsteil      string  `yaml:"ortsteil,omitempty" json:"ortsteil,omitempty"`
+	Stadtbezirk   string  `yaml:"stadtbezirk,omitempty" json:"stadtbezirk,omitempty"`
+	Lat           float64 `yaml:"lat,omitempty" json:"lat,omitempty"`
+	Lon           float64 `yaml:"lon,omitempty" json:"lon,omitempty"`
+	Accessibility string  `yaml:"accessibility,omitempty" json:"accessibility,omitempty"`
+	Homepage      string  `yaml:"homepage,omitempty" json:"homepage,omitempty"`
+}
+
+// HasCoords reports whether the venue's location is known.
+func (v Venue) HasCoords() bool {
+	return v.Lat != 0 || v.Lon != 0
+}
+
+type file struct {
+	Venues []Venue `yaml:"venues"`
+}
+
+// Registry looks venues up by ID or by any of their names.
+type Registry struct {
+	venues []Venue
+	byKey  map[string]int // Key(name or alias) -> index
+}
+
+// Default returns the built-in registry.
+func Default() *Registry {
+	r := &Registry{}
+	if err := r.add(builtin, "built-in venues"); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Load returns the built-in registry extended with the venues in path,
+// which uses the same format as the built-in venues.yaml. Entries with an
+// existing ID replace the built-in one. A missing file is not an error.
+func Load(path string) (*Registry, error) {
+	r := Default()
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return r, nil
+	case err != nil:
+		return r, fmt.Errorf("venue: read %s: %w", path, err)
+	}
+	if err := r.add(data, path); err != nil {
+		return Default(), err
+	}
+	return r, nil
+}
+
+func (r *Registry) add(data []byte, name string) error {
+	var f file
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("venue: parse %s: %w", name, err)
+	}
+	for _, v := range f.Venues {
+		if v.ID == "" || v.Name == "" {
+			return fmt.Errorf("venue: %s: every venue needs an id and a name", name)
+		}
+		if i := r.index(v.ID); i >= 0 {
+			r.venues[i] = v
+		} else {
+			r.venues = append(r.venues, v)
+		}
+	}
+	r.reindex()
+	return nil
+}
+
+func (r *Registry) index(id string) int {
+	for i, v := range r.venues {
+		if v.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Registry) reindex() {
+	r.byKey = make(map[string]int)
+	for i, v := range r.venues {
+		for _, name := range append([]string{v.Name}, v.Aliases...) {
+			if k := Key(name); k != "" {
+				r.byKey[k] = i
+			}
+		}
+	}
+}
+
+// All returns every venue, sorted by name.
+func (r *Registry) All() []Venue {
+	all := append([]Venue(nil), r.venues...)
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+// Get returns the venue with the given ID.
+func (r *Registry) Get(id string) (Venue, bool) {
+	if i := r.index(id); i >= 0 {
+		return r.venues[i], true
+	}
+	return Venue{}, false
+}
+
+// Match finds the venue a source's venue text refers to: its name or an
+// alias, optionally followed by a room ("Gewandhaus – Großer Saal") or
+// further words ("Conne Island Saal").
+func (r *Registry) Match(name string) (Venue, bool) {
+	k := Key(name)
+	if k == "" {
+		return Venue{}, false
+	}
+	if i, ok := r.byKey[k]; ok {
+		return r.venues[i], true
+	}
+	// Longest name that starts the text, on a word boundary. Short names
+	// ("ut", "oper") must match exactly.
+	best, bestLen := -1, minPrefixLen-1
+	for key, i := range r.byKey {
+		if !strings.HasPrefix(k, key+" ") {
+			continue
+		}
+		if len(key) > bestLen || len(key) == bestLen && i < best {
+			best, bestLen = i, len(key)
+		}
+	}
+	if best >= 0 {
+		return r.venues[best], true
+	}
+	return Venue{}, false
+}
+
+// Enrich links e to its venue, if known: it sets VenueID, the coordinates
+// and the district, and fills in a missing address.
+func (r *Registry) Enrich(e *model.Event) {
+	v, ok := r.Match(e.Venue)
+	if !ok {
+		return
+	}
+	e.VenueID = v.ID
+	if v.HasCoords() {
+		e.Lat, e.Lon = v.Lat, v.Lon
+	}
+	if e.Address == "" {
+		e.Address = v.Address
+	}
+	if v.Ortsteil != "" {
+		e.District = v.Ortsteil
+	}
+}
+
+// Key normalizes a venue name for comparison: lowercase, umlauts folded,
+// punctuation and filler words ("zu Leipzig") dropped, and anything after
+// a room separator (dash, comma, parenthesis) cut off.
+func Key(name string) string {
+	if i := strings.IndexAny(name, ",(–—|"); i > 0 {
+		name = name[:i]
+	}
+	if before, _, ok := strings.Cut(name, " - "); ok && before != "" {
+		name = before
+	}
+	name = strings.NewReplacer("ä", "a", "ö", "o", "ü", "u", "ß", "ss").Replace(strings.ToLower(name))
+	fields := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := fields[:0]
+	for _, w := range fields {
+		if !filler[w] {
+			kept = append(kept, w)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+var filler = map[string]bool{
+	"zu": true, "in": true, "im": true, "der": true, "die": true, "das": true, "leipzig": true,
+}
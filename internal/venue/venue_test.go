@@ -0,0 +1,90 @@
+package venue
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/havocked/leipzig-cli/internal/district"
+	"github.com/havocked/leipzig-cli/internal/model"
+)
+
+func TestMatch(t *testing.T) {
+	r := Default()
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Gewandhaus zu Leipzig", "gewandhaus"},
+		{"Gewandhaus – Großer Saal", "gewandhaus"},
+		{"GEWANDHAUS, Mendelssohn-Saal", "gewandhaus"},
+		{"Opernhaus", "oper-leipzig"},
+		{"Werk II", "werk-2"},
+		{"Conne Island Saal", "conne-island"},
+		{"Täubchenthal", "taeubchenthal"},
+		{"Taubchenthal", "taeubchenthal"},
+		{"UT", "ut-connewitz"},
+		{"Utopia Bar", ""},
+		{"Oper Halle", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		v, ok := r.Match(tt.name)
+		if got := v.ID; got != tt.want || ok != (tt.want != "") {
+			t.Errorf("Match(%q) = %q, %v; want %q", tt.name, got, ok, tt.want)
+		}
+	}
+}
+
+func TestBuiltinVenues(t *testing.T) {
+	seen := make(map[string]string)
+	for _, v := range Default().All() {
+		if !v.HasCoords() || v.Address == "" || v.Ortsteil == "" || v.Stadtbezirk == "" {
+			t.Errorf("%s: missing address, district or coordinates", v.ID)
+		}
+		if v.Lat < 51.2 || v.Lat > 51.5 || v.Lon < 12.2 || v.Lon > 12.6 {
+			t.Errorf("%s: %.4f,%.4f is not in Leipzig", v.ID, v.Lat, v.Lon)
+		}
+		if d, ok := district.Lookup(v.Ortsteil); !ok || d.Name != v.Ortsteil || d.Parent != v.Stadtbezirk {
+			t.Errorf("%s: %s is not an Ortsteil of %s", v.ID, v.Ortsteil, v.Stadtbezirk)
+		}
+		for _, name := range append([]string{v.Name}, v.Aliases...) {
+			if other, ok := seen[Key(name)]; ok && other != v.ID {
+				t.Errorf("%q names both %s and %s", name, other, v.ID)
+			}
+			seen[Key(name)] = v.ID
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "venues.yaml")
+	data := `venues:
+  - id: gewandhaus
+    name: Gewandhaus
+    address: Augustusplatz 8, 04109 Leipzig
+  - id: frau-krause
+    name: Frau Krause
+    aliases: [Kneipe Frau Krause]
+    lat: 51.3272
+    lon: 12.3751
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := r.Get("gewandhaus"); v.HasCoords() {
+		t.Error("user entry didn't replace the built-in one")
+	}
+	e := model.Event{Venue: "Kneipe Frau Krause"}
+	r.Enrich(&e)
+	if e.VenueID != "frau-krause" || e.Lat != 51.3272 {
+		t.Errorf("Enrich = %+v", e)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err != nil {
+		t.Errorf("missing file: %v", err)
+	}
+}